@@ -0,0 +1,145 @@
+// Package dfpwm implements the DFPWM1a audio codec, compatible with cc.audio.dfpwm
+// of CC: Tweaked. DFPWM encodes 8 signed 8-bit samples into a single byte, the
+// least significant bit is the first sample.
+package dfpwm
+
+const (
+	// precision is the precision of the adaptive strength of the predictor
+	precision   = 10
+	strengthMax = 1<<precision - 1
+	strengthMin = 1 << (precision - 8)
+
+	// lowPassStrength is the strength of the low-pass filter of the decoder (out of 256)
+	lowPassStrength = 140
+)
+
+// floorDiv divides like lua's math.floor(a / b)
+func floorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// predictor models the charge of a capacitor, that is charged (bit set) or
+// discharged (bit not set) with an adaptive strength.
+type predictor struct {
+	charge      int
+	strength    int
+	previousBit bool
+}
+
+func (p *predictor) next(bit bool) int {
+	target := -128
+	if bit {
+		target = 127
+	}
+
+	charge := p.charge + floorDiv(p.strength*(target-p.charge)+(1<<(precision-1)), 1<<precision)
+	if charge == p.charge && charge != target {
+		if bit {
+			charge++
+		} else {
+			charge--
+		}
+	}
+
+	z := 0
+	if bit == p.previousBit {
+		z = strengthMax
+	}
+	strength := p.strength
+	if strength != z {
+		if bit == p.previousBit {
+			strength++
+		} else {
+			strength--
+		}
+	}
+	if strength < strengthMin {
+		strength = strengthMin
+	}
+
+	p.charge, p.strength, p.previousBit = charge, strength, bit
+	return charge
+}
+
+// Encoder encodes signed 8-bit samples. It keeps its state between calls,
+// so a stream can be encoded in chunks.
+type Encoder struct {
+	predictor      predictor
+	previousCharge int
+}
+
+func NewEncoder() *Encoder {
+	return &Encoder{}
+}
+
+// Encode appends the encoded samples to dst and returns the extended slice. If the number of
+// samples is not a multiple of 8, the last byte is padded with silence.
+func (e *Encoder) Encode(dst []byte, samples []int8) []byte {
+	for i := 0; i < len(samples); i += 8 {
+		var b byte
+		for j := 0; j < 8; j++ {
+			var sample int
+			if i+j < len(samples) {
+				sample = int(samples[i+j])
+			}
+
+			bit := sample > e.previousCharge || (sample == e.previousCharge && e.previousCharge == 127)
+			b >>= 1
+			if bit {
+				b |= 0x80
+			}
+			e.previousCharge = e.predictor.next(bit)
+		}
+		dst = append(dst, b)
+	}
+	return dst
+}
+
+// Decoder decodes DFPWM into signed 8-bit samples. It keeps its state between calls,
+// so a stream can be decoded in chunks.
+type Decoder struct {
+	predictor      predictor
+	lowPassCharge  int
+	previousCharge int
+	previousBit    bool
+}
+
+func NewDecoder() *Decoder {
+	return &Decoder{}
+}
+
+// Decode appends 8 samples per byte of data to dst and returns the extended slice.
+func (d *Decoder) Decode(dst []int8, data []byte) []int8 {
+	for _, b := range data {
+		for j := 0; j < 8; j++ {
+			bit := b&1 != 0
+			charge := d.predictor.next(bit)
+
+			// smooth the change of direction
+			antijerk := charge
+			if bit != d.previousBit {
+				antijerk = floorDiv(charge+d.previousCharge+1, 2)
+			}
+			d.previousCharge, d.previousBit = charge, bit
+
+			d.lowPassCharge += floorDiv((antijerk-d.lowPassCharge)*lowPassStrength+0x80, 256)
+			dst = append(dst, int8(d.lowPassCharge))
+			b >>= 1
+		}
+	}
+	return dst
+}
+
+// Encode encodes samples with a new encoder, like cc.audio.dfpwm.encode
+func Encode(samples []int8) []byte {
+	return NewEncoder().Encode(make([]byte, 0, (len(samples)+7)/8), samples)
+}
+
+// Decode decodes data with a new decoder, like cc.audio.dfpwm.decode
+func Decode(data []byte) []int8 {
+	return NewDecoder().Decode(make([]int8, 0, len(data)*8), data)
+}
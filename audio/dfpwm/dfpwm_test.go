@@ -0,0 +1,201 @@
+package dfpwm
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func repeat[T any](value T, n int) []T {
+	values := make([]T, n)
+	for i := range values {
+		values[i] = value
+	}
+	return values
+}
+
+// sine returns n samples of a 440Hz sine wave at 48kHz
+func sine(n int) []int8 {
+	samples := make([]int8, n)
+	for i := range samples {
+		samples[i] = int8(100 * math.Sin(2*math.Pi*440*float64(i)/48000))
+	}
+	return samples
+}
+
+func TestEncode(t *testing.T) {
+	tests := []struct {
+		name     string
+		samples  []int8
+		expected []byte
+	}{
+		// the predictor oscillates around 0, so the bits alternate starting with 0
+		{name: "silence", samples: repeat[int8](0, 16), expected: []byte{0xAA, 0xAA}},
+		{name: "max", samples: repeat[int8](127, 16), expected: []byte{0xFF, 0xFF}},
+		{name: "min", samples: repeat[int8](-128, 16), expected: []byte{0x00, 0x00}},
+		// missing samples are padded with silence
+		{name: "padded", samples: repeat[int8](127, 4), expected: []byte{0x0F}},
+		{name: "empty", samples: nil, expected: []byte{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//act
+			actual := Encode(tt.samples)
+
+			//assert
+			if !bytes.Equal(tt.expected, actual) {
+				t.Logf("expected: %x", tt.expected)
+				t.Logf("actual  : %x", actual)
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestDecode_silence(t *testing.T) {
+	//arrange
+	var expected = []int8{-1, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 0}
+
+	//act
+	actual := Decode([]byte{0xAA, 0xAA})
+
+	//assert
+	if !reflect.DeepEqual(expected, actual) {
+		t.Logf("expected: %v", expected)
+		t.Logf("actual  : %v", actual)
+		t.FailNow()
+	}
+}
+
+func TestDecode_max(t *testing.T) {
+	//act
+	actual := Decode(repeat[byte](0xFF, 64))
+
+	//assert
+	if actual[len(actual)-1] != 127 {
+		t.Logf("expected: %v", 127)
+		t.Logf("actual  : %v", actual[len(actual)-1])
+		t.FailNow()
+	}
+	for i := 1; i < len(actual); i++ {
+		if actual[i] < actual[i-1] {
+			t.Logf("sample %v decreased from %v to %v", i, actual[i-1], actual[i])
+			t.FailNow()
+		}
+	}
+}
+
+func TestRoundTrip_sine(t *testing.T) {
+	//arrange
+	samples := sine(4800)
+
+	//act
+	actual := Decode(Encode(samples))
+
+	//assert
+	if len(actual) != len(samples) {
+		t.Logf("expected: %v samples", len(samples))
+		t.Logf("actual  : %v samples", len(actual))
+		t.FailNow()
+	}
+	// the first samples are skipped, while the predictor adapts to the signal
+	var squared float64
+	for i := 480; i < len(samples); i++ {
+		diff := float64(actual[i]) - float64(samples[i])
+		squared += diff * diff
+	}
+	rms := math.Sqrt(squared / float64(len(samples)-480))
+	if rms > 20 {
+		t.Logf("expected: rms error <= 20")
+		t.Logf("actual  : %v", rms)
+		t.FailNow()
+	}
+}
+
+func TestEncoder_chunked(t *testing.T) {
+	//arrange
+	samples := sine(1000)
+	expected := Encode(samples)
+
+	//act
+	encoder := NewEncoder()
+	var actual []byte
+	for i := 0; i < len(samples); i += 64 {
+		end := i + 64
+		if end > len(samples) {
+			end = len(samples)
+		}
+		actual = encoder.Encode(actual, samples[i:end])
+	}
+
+	//assert
+	if !bytes.Equal(expected, actual) {
+		t.Logf("expected: %x", expected)
+		t.Logf("actual  : %x", actual)
+		t.FailNow()
+	}
+}
+
+func TestWriter(t *testing.T) {
+	//arrange
+	samples := sine(1001)
+	expected := Encode(samples)
+	var buf bytes.Buffer
+
+	//act
+	w := NewWriter(&buf)
+	for i := 0; i < len(samples); i += 13 {
+		end := i + 13
+		if end > len(samples) {
+			end = len(samples)
+		}
+		_, err := w.WriteSamples(samples[i:end])
+		if err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+	}
+	err := w.Close()
+
+	//assert
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+	if !bytes.Equal(expected, buf.Bytes()) {
+		t.Logf("expected: %x", expected)
+		t.Logf("actual  : %x", buf.Bytes())
+		t.FailNow()
+	}
+}
+
+func TestReader(t *testing.T) {
+	//arrange
+	data := Encode(sine(1000))
+	expected := Decode(data)
+
+	//act
+	r := NewReader(bytes.NewReader(data))
+	var actual []int8
+	buf := make([]int8, 13)
+	for {
+		n, err := r.ReadSamples(buf)
+		actual = append(actual, buf[:n]...)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Log(err)
+			t.FailNow()
+		}
+	}
+
+	//assert
+	if !reflect.DeepEqual(expected, actual) {
+		t.Logf("expected: %v", expected)
+		t.Logf("actual  : %v", actual)
+		t.FailNow()
+	}
+}
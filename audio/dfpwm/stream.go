@@ -0,0 +1,113 @@
+package dfpwm
+
+import (
+	"io"
+)
+
+// Writer encodes signed 8-bit samples written to it (one sample per byte) and writes the DFPWM
+// data to the underlying writer. Close has to be called to write the last incomplete byte.
+type Writer struct {
+	w       io.Writer
+	encoder *Encoder
+	pending []int8
+	buf     []byte
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{w: w, encoder: NewEncoder()}
+}
+
+// Write encodes p, every byte is interpreted as a signed 8-bit sample.
+func (w *Writer) Write(p []byte) (int, error) {
+	for _, sample := range p {
+		w.pending = append(w.pending, int8(sample))
+	}
+
+	// only complete bytes are encoded, the rest is kept for the next write
+	complete := len(w.pending) - len(w.pending)%8
+	if complete == 0 {
+		return len(p), nil
+	}
+
+	w.buf = w.encoder.Encode(w.buf[:0], w.pending[:complete])
+	w.pending = append(w.pending[:0], w.pending[complete:]...)
+	_, err := w.w.Write(w.buf)
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// WriteSamples encodes samples
+func (w *Writer) WriteSamples(samples []int8) (int, error) {
+	p := make([]byte, len(samples))
+	for i, sample := range samples {
+		p[i] = byte(sample)
+	}
+	return w.Write(p)
+}
+
+// Close encodes the remaining samples, padded with silence. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if len(w.pending) == 0 {
+		return nil
+	}
+	w.buf = w.encoder.Encode(w.buf[:0], w.pending)
+	w.pending = w.pending[:0]
+	_, err := w.w.Write(w.buf)
+	return err
+}
+
+// Reader decodes DFPWM data of the underlying reader. Every byte read is a signed 8-bit sample.
+type Reader struct {
+	r       io.Reader
+	decoder *Decoder
+	samples []int8
+	buf     []byte
+}
+
+func NewReader(r io.Reader) *Reader {
+	return &Reader{r: r, decoder: NewDecoder()}
+}
+
+// Read decodes up to len(p) samples into p.
+func (r *Reader) Read(p []byte) (int, error) {
+	if len(p) == 0 {
+		return 0, nil
+	}
+
+	if len(r.samples) == 0 {
+		size := (len(p) + 7) / 8
+		if cap(r.buf) < size {
+			r.buf = make([]byte, size)
+		}
+		n, err := r.r.Read(r.buf[:size])
+		if n == 0 {
+			if err == nil {
+				err = io.ErrNoProgress
+			}
+			return 0, err
+		}
+		r.samples = r.decoder.Decode(r.samples[:0], r.buf[:n])
+	}
+
+	n := len(p)
+	if n > len(r.samples) {
+		n = len(r.samples)
+	}
+	for i, sample := range r.samples[:n] {
+		p[i] = byte(sample)
+	}
+	r.samples = r.samples[n:]
+	return n, nil
+}
+
+// ReadSamples decodes up to len(samples) samples into samples.
+func (r *Reader) ReadSamples(samples []int8) (int, error) {
+	p := make([]byte, len(samples))
+	n, err := r.Read(p)
+	for i := 0; i < n; i++ {
+		samples[i] = int8(p[i])
+	}
+	return n, err
+}
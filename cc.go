@@ -54,7 +54,7 @@ func NewComputer(conn connection.Connection) (computer.Computer, error) {
 		return nil, fmt.Errorf("connection is nil")
 	}
 
-	panic("not implemented")
+	return computer.NewComputer(conn), nil
 }
 
 func NewTurtle(conn connection.Connection) (computer.Turtle, error) {
@@ -62,13 +62,13 @@ func NewTurtle(conn connection.Connection) (computer.Turtle, error) {
 		return nil, fmt.Errorf("connection is nil")
 	}
 
-	panic("not implemented")
+	return computer.NewTurtle(conn), nil
 }
 
-func NewPocket(conn connection.Connection) (computer.Computer, error) {
+func NewPocket(conn connection.Connection) (computer.Pocket, error) {
 	if conn == nil {
 		return nil, fmt.Errorf("connection is nil")
 	}
 
-	panic("not implemented")
+	return computer.NewPocket(conn), nil
 }
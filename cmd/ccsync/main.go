@@ -0,0 +1,154 @@
+// Command ccsync deploys a local directory to all connected computers.
+//
+// It starts a hub the lua runtime connects to, waits for computers to connect,
+// compares the local files with the files of every selected computer by their
+// crc32 checksums and uploads only the files that changed.
+//
+//	ccsync -src ./lua/lib -dst /lib -label "miner-*" -type turtle -delete
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"github.com/m4schini/computercraft-go/computer"
+	"github.com/m4schini/computercraft-go/hub"
+	"io/fs"
+	"log"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+type config struct {
+	src     string
+	dst     string
+	del     bool
+	dryRun  bool
+	exclude []string
+	timeout time.Duration
+}
+
+func main() {
+	listen := flag.String("listen", ":8080", "address of the hub, the runtime connects to ws://<host>/api/ws")
+	wait := flag.Duration("wait", 10*time.Second, "time to wait for computers to connect")
+	label := flag.String("label", "", "only sync computers with a label matching this glob")
+	types := flag.String("type", "", "only sync devices of these types (comma separated: computer, turtle, pocket)")
+	exclude := flag.String("exclude", ".git,.config,startup", "comma separated globs of files that are never uploaded or deleted")
+	var cfg config
+	flag.StringVar(&cfg.src, "src", ".", "local directory")
+	flag.StringVar(&cfg.dst, "dst", "/", "directory on the computers")
+	flag.BoolVar(&cfg.del, "delete", false, "delete files on the computers, that do not exist locally")
+	flag.BoolVar(&cfg.dryRun, "dry-run", false, "only print what would be done")
+	flag.DurationVar(&cfg.timeout, "timeout", time.Minute, "timeout per computer")
+	flag.Parse()
+
+	cfg.exclude = splitList(*exclude)
+	var deviceTypes []computer.DeviceType
+	for _, t := range splitList(*types) {
+		deviceTypes = append(deviceTypes, computer.DeviceType(t))
+	}
+
+	src := os.DirFS(cfg.src)
+	local, err := localChecksums(src, cfg.exclude)
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	h := hub.New()
+	mux := http.NewServeMux()
+	mux.Handle("/api/ws", h)
+	go func() {
+		log.Fatalln(http.ListenAndServe(*listen, mux))
+	}()
+
+	log.Printf("waiting %v for computers to connect to %v", *wait, *listen)
+	time.Sleep(*wait)
+
+	devices := h.Select(hub.LabelGlob(*label), hub.OfType(deviceTypes...))
+	if len(devices) == 0 {
+		log.Fatalln("no matching computers connected")
+	}
+
+	failed := 0
+	for _, d := range devices {
+		err := syncDevice(d, src, local, cfg)
+		if err != nil {
+			failed++
+			log.Printf("%v: %v", describe(d), err)
+		}
+	}
+	if failed > 0 {
+		log.Fatalf("%v of %v computers failed", failed, len(devices))
+	}
+}
+
+func syncDevice(d *hub.Device, src fs.FS, local map[string]uint32, cfg config) error {
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.timeout)
+	defer cancel()
+
+	fsys := computer.NewFileSystem(d.Conn)
+	remote, err := fsys.Checksums(ctx, cfg.dst)
+	if err != nil {
+		return err
+	}
+
+	plan := diff(local, remote, cfg.del, cfg.exclude)
+	if plan.Empty() {
+		log.Printf("%v: up to date", describe(d))
+		return nil
+	}
+
+	for _, name := range plan.Upload {
+		log.Printf("%v: upload %v", describe(d), name)
+		if cfg.dryRun {
+			continue
+		}
+		err = upload(ctx, fsys, src, name, path.Join(cfg.dst, name))
+		if err != nil {
+			return err
+		}
+	}
+	for _, name := range plan.Delete {
+		log.Printf("%v: delete %v", describe(d), name)
+		if cfg.dryRun {
+			continue
+		}
+		err = fsys.Delete(ctx, path.Join(cfg.dst, name))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func upload(ctx context.Context, fsys computer.FileSystem, src fs.FS, name, dst string) error {
+	f, err := src.Open(name)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	_, err = fsys.WriteFile(ctx, dst, f)
+	return err
+}
+
+func describe(d *hub.Device) string {
+	if d.Label == "" {
+		return fmt.Sprintf("%v #%v", d.Type, d.ID)
+	}
+	return fmt.Sprintf("%v #%v (%v)", d.Type, d.ID, d.Label)
+}
+
+func splitList(s string) []string {
+	var items []string
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
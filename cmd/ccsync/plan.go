@@ -0,0 +1,85 @@
+package main
+
+import (
+	"hash/crc32"
+	"io/fs"
+	"path"
+	"sort"
+)
+
+// Plan lists the files that have to be uploaded to or deleted from a computer
+type Plan struct {
+	Upload []string
+	Delete []string
+}
+
+// Empty is true if the computer is already in sync
+func (p Plan) Empty() bool {
+	return len(p.Upload) == 0 && len(p.Delete) == 0
+}
+
+// excluded checks if a file matches one of the patterns, either with its full
+// (slash separated) path or with its base name.
+func excluded(name string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, path.Base(name)); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// localChecksums returns the crc32 checksums of all files of fsys, that are not excluded
+func localChecksums(fsys fs.FS, exclude []string) (map[string]uint32, error) {
+	sums := make(map[string]uint32)
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if name == "." {
+			return nil
+		}
+		if excluded(name, exclude) {
+			if d.IsDir() {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		sums[name] = crc32.ChecksumIEEE(content)
+		return nil
+	})
+	return sums, err
+}
+
+// diff compares the local files with the files of a computer. Remote files that do not
+// exist locally are only deleted if del is true. Excluded remote files are never touched.
+func diff(local, remote map[string]uint32, del bool, exclude []string) Plan {
+	var plan Plan
+	for name, sum := range local {
+		remoteSum, ok := remote[name]
+		if !ok || remoteSum != sum {
+			plan.Upload = append(plan.Upload, name)
+		}
+	}
+	if del {
+		for name := range remote {
+			if _, ok := local[name]; !ok && !excluded(name, exclude) {
+				plan.Delete = append(plan.Delete, name)
+			}
+		}
+	}
+	sort.Strings(plan.Upload)
+	sort.Strings(plan.Delete)
+	return plan
+}
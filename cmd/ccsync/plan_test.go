@@ -0,0 +1,62 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLocalChecksums(t *testing.T) {
+	//arrange
+	var expected = map[string]uint32{"lib/inv.lua": 0x0d4a1185}
+	fsys := fstest.MapFS{
+		"lib/inv.lua":     {Data: []byte("hello world")},
+		".git/HEAD":       {Data: []byte("ref: refs/heads/main")},
+		"lib/.config":     {Data: []byte("{}")},
+		"notes/README.md": {Data: []byte("# notes")},
+	}
+
+	//act
+	actual, err := localChecksums(fsys, []string{".git", ".config", "*.md"})
+
+	//assert
+	t.Logf("expected: %v", expected)
+	t.Logf("  actual: %v", actual)
+	if err != nil || !reflect.DeepEqual(expected, actual) {
+		t.FailNow()
+	}
+}
+
+func TestDiff(t *testing.T) {
+	//arrange
+	var expected = Plan{
+		Upload: []string{"changed.lua", "new.lua"},
+		Delete: []string{"old.lua"},
+	}
+	local := map[string]uint32{"same.lua": 1, "changed.lua": 2, "new.lua": 3}
+	remote := map[string]uint32{"same.lua": 1, "changed.lua": 20, "old.lua": 4, "startup": 5}
+
+	//act
+	actual := diff(local, remote, true, []string{"startup"})
+
+	//assert
+	t.Logf("expected: %+v", expected)
+	t.Logf("  actual: %+v", actual)
+	if !reflect.DeepEqual(expected, actual) {
+		t.FailNow()
+	}
+}
+
+func TestDiff_keepExtraneous(t *testing.T) {
+	//arrange
+	local := map[string]uint32{"same.lua": 1}
+	remote := map[string]uint32{"same.lua": 1, "old.lua": 4}
+
+	//act
+	actual := diff(local, remote, false, nil)
+
+	//assert
+	if !actual.Empty() {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,111 @@
+package colors
+
+import (
+	"math/bits"
+	"strings"
+)
+
+// Color is one of the 16 colors of the colors api. Colors are bit flags, so a
+// combination of colors (e.g. for bundled cables) is a Color as well.
+type Color uint16
+
+const (
+	White Color = 1 << iota
+	Orange
+	Magenta
+	LightBlue
+	Yellow
+	Lime
+	Pink
+	Gray
+	LightGray
+	Cyan
+	Purple
+	Blue
+	Brown
+	Green
+	Red
+	Black
+)
+
+const blitChars = "0123456789abcdef"
+
+var names = [16]string{
+	"white", "orange", "magenta", "lightBlue", "yellow", "lime", "pink", "gray",
+	"lightGray", "cyan", "purple", "blue", "brown", "green", "red", "black",
+}
+
+// All returns all 16 colors in the order of their bit value
+func All() []Color {
+	all := make([]Color, 16)
+	for i := range all {
+		all[i] = 1 << i
+	}
+	return all
+}
+
+// Combine combines a set of colors (or sets of colors) into a larger set.
+func Combine(colors ...Color) Color {
+	var c Color
+	for _, color := range colors {
+		c |= color
+	}
+	return c
+}
+
+// Subtract removes one or more colors (or sets of colors) from a set.
+func (c Color) Subtract(colors ...Color) Color {
+	return c &^ Combine(colors...)
+}
+
+// Test checks if all given colors are part of the set.
+func (c Color) Test(color Color) bool {
+	return c&color == color
+}
+
+// Single checks if c is exactly one color.
+func (c Color) Single() bool {
+	return bits.OnesCount16(uint16(c)) == 1
+}
+
+// Colors splits a set into its single colors.
+func (c Color) Colors() []Color {
+	colors := make([]Color, 0, bits.OnesCount16(uint16(c)))
+	for _, color := range All() {
+		if c.Test(color) {
+			colors = append(colors, color)
+		}
+	}
+	return colors
+}
+
+// Blit returns the hexadecimal character used by term.blit for a single color.
+func (c Color) Blit() byte {
+	if !c.Single() {
+		return ' '
+	}
+	return blitChars[bits.TrailingZeros16(uint16(c))]
+}
+
+// FromBlit returns the color of a term.blit character.
+func FromBlit(b byte) (Color, bool) {
+	i := strings.IndexByte(blitChars, b)
+	if i < 0 && b >= 'A' && b <= 'F' {
+		i = strings.IndexByte(blitChars, b-'A'+'a')
+	}
+	if i < 0 {
+		return 0, false
+	}
+	return 1 << i, true
+}
+
+func (c Color) String() string {
+	if c == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, 1)
+	for _, color := range c.Colors() {
+		parts = append(parts, names[bits.TrailingZeros16(uint16(color))])
+	}
+	return strings.Join(parts, "|")
+}
@@ -0,0 +1,190 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/m4schini/computercraft-go/computer"
+	"github.com/m4schini/computercraft-go/connection"
+	"strings"
+)
+
+const (
+	ModuleName = "commands"
+
+	// TaskCompleteEvent is queued when a command started with commands.execAsync completed
+	TaskCompleteEvent = "task_complete"
+
+	// MaxBlockInfos is the maximum number of blocks, that can be requested by BlockInfos at once
+	MaxBlockInfos = 4096
+)
+
+var ErrNotCommandComputer = errors.New("not a command computer")
+
+// module evaluates to the commands module, or raises ErrNotCommandComputer
+var module = fmt.Sprintf("(%v or error(%v, 0))", ModuleName, connection.LuaString(ErrNotCommandComputer.Error()))
+
+// Result is the result of a command
+type Result struct {
+	// Success is false if the command failed
+	Success bool
+	// Output are the messages the command sent
+	Output []string
+	// Affected is the number of affected blocks, entities etc. as reported by the command
+	Affected int
+}
+
+// IsCommandComputer checks if the computer is a command computer
+func IsCommandComputer(ctx context.Context, conn connection.Connection) (bool, error) {
+	return connection.DoActionBool(ctx, conn, ModuleName+" ~= nil")
+}
+
+func call(ctx context.Context, conn connection.Connection, function string, args ...any) ([]any, error) {
+	arguments, err := connection.LuaArgs(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := conn.Execute(ctx, fmt.Sprintf("%v.%v(%v)", module, function, arguments))
+	if err != nil {
+		if strings.Contains(err.Error(), ErrNotCommandComputer.Error()) {
+			return nil, ErrNotCommandComputer
+		}
+		return nil, connection.RpcError(err)
+	}
+	return res, nil
+}
+
+// decodeResult decodes the success, output and affected count returned by commands.exec
+func decodeResult(values []any) (result Result, err error) {
+	err = connection.Unpack(values, &result.Success, &result.Output, &result.Affected)
+	return result, err
+}
+
+// Exec runs a command, like it was run from a command block, and waits until it completed.
+func Exec(ctx context.Context, conn connection.Connection, command string) (Result, error) {
+	res, err := call(ctx, conn, "exec", command)
+	if err != nil {
+		return Result{}, err
+	}
+	return decodeResult(res)
+}
+
+// Task is a command running asynchronously, see ExecAsync
+type Task struct {
+	ID int
+
+	done   chan struct{}
+	result Result
+	err    error
+}
+
+// ExecAsync starts a command without waiting until it completed. The task is only tracked until ctx is done.
+func ExecAsync(ctx context.Context, conn connection.Connection, command string) (*Task, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	events, err := conn.Subscribe(ctx, TaskCompleteEvent)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	res, err := call(ctx, conn, "execAsync", command)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	t := &Task{done: make(chan struct{})}
+	err = connection.Unpack(res, &t.ID)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		defer cancel()
+		for event := range events {
+			var id int
+			if event.Arg(0, &id) != nil || id != t.ID {
+				continue
+			}
+
+			// the event contains the id, whether the task ran, and the results of the command or an error
+			var ran bool
+			_ = event.Arg(1, &ran)
+			if ran {
+				t.result, t.err = decodeResult(event.Args[2:])
+			} else {
+				var message string
+				_ = event.Arg(2, &message)
+				t.err = connection.RpcError(errors.New(message))
+			}
+			close(t.done)
+			return
+		}
+	}()
+	return t, nil
+}
+
+// Done is closed when the command completed
+func (t *Task) Done() <-chan struct{} {
+	return t.done
+}
+
+// Wait waits until the command completed and returns its result
+func (t *Task) Wait(ctx context.Context) (Result, error) {
+	select {
+	case <-t.done:
+		return t.result, t.err
+	case <-ctx.Done():
+		return Result{}, ctx.Err()
+	}
+}
+
+// BlockPosition returns the position of the command computer
+func BlockPosition(ctx context.Context, conn connection.Connection) (x, y, z int, err error) {
+	res, err := call(ctx, conn, "getBlockPosition")
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	err = connection.Unpack(res, &x, &y, &z)
+	return x, y, z, err
+}
+
+// BlockInfo returns the block at a position in the world of the command computer
+func BlockInfo(ctx context.Context, conn connection.Connection, x, y, z int) (computer.Block, error) {
+	res, err := call(ctx, conn, "getBlockInfo", x, y, z)
+	if err != nil {
+		return nil, err
+	}
+
+	var block computer.Block
+	err = connection.Unpack(res, &block)
+	return block, err
+}
+
+// BlockInfos returns all blocks of the cuboid between two corners. The blocks are ordered
+// by y, then z, then x, so the block at x, y, z has the index
+// (y-minY)*depth*width + (z-minZ)*width + (x-minX). At most MaxBlockInfos blocks can be requested.
+func BlockInfos(ctx context.Context, conn connection.Connection, x1, y1, z1, x2, y2, z2 int) ([]computer.Block, error) {
+	count := (abs(x2-x1) + 1) * (abs(y2-y1) + 1) * (abs(z2-z1) + 1)
+	if count > MaxBlockInfos {
+		return nil, fmt.Errorf("cannot request %v blocks, the maximum is %v", count, MaxBlockInfos)
+	}
+
+	res, err := call(ctx, conn, "getBlockInfos", x1, y1, z1, x2, y2, z2)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []computer.Block
+	err = connection.Unpack(res, &blocks)
+	return blocks, err
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
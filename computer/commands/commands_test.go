@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/test"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestExec(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{true, []any{"Set the time to 1000"}, 1.0})
+	var expected = Result{Success: true, Output: []string{"Set the time to 1000"}, Affected: 1}
+
+	//act
+	actual, err := Exec(context.TODO(), conn, "time set 1000")
+
+	//assert
+	t.Logf("expected: %+v", expected)
+	t.Logf("actual  : %+v", actual)
+	if err != nil || !reflect.DeepEqual(expected, actual) {
+		t.FailNow()
+	}
+	if command := conn.Executed()[0]; !strings.HasSuffix(command, `.exec("time set 1000")`) {
+		t.Logf("actual: %v", command)
+		t.FailNow()
+	}
+}
+
+func TestExecAsync(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{7.0})
+	var expected = Result{Success: false, Output: []string{"Unknown command"}}
+
+	//act
+	task, err := ExecAsync(context.TODO(), conn, "foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Emit(TaskCompleteEvent, 6.0, true, true, []any{}, 0.0)
+	conn.Emit(TaskCompleteEvent, 7.0, true, false, []any{"Unknown command"})
+	actual, err := task.Wait(context.TODO())
+
+	//assert
+	t.Logf("expected: %+v", expected)
+	t.Logf("actual  : %+v", actual)
+	if err != nil || task.ID != 7 || !reflect.DeepEqual(expected, actual) {
+		t.FailNow()
+	}
+}
+
+func TestBlockInfos_tooMany(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+
+	//act
+	_, err := BlockInfos(context.TODO(), conn, 0, 0, 0, 15, 16, 15)
+
+	//assert
+	t.Logf("actual: %v", err)
+	if err == nil || len(conn.Executed()) != 0 {
+		t.FailNow()
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"github.com/m4schini/computercraft-go/computer/settings"
 	"github.com/m4schini/computercraft-go/connection"
 	"time"
 )
@@ -56,8 +57,9 @@ func ComputerLabel(ctx context.Context, conn connection.Connection) (string, err
 		return "", connection.RpcError(err)
 	}
 
-	if len(res) != 1 {
-		return "", errors.New("something went wrong")
+	// computers without a label return nil
+	if len(res) == 0 || res[0] == nil {
+		return "", nil
 	}
 
 	label, ok := res[0].(string)
@@ -89,35 +91,41 @@ func Uptime(ctx context.Context, conn connection.Connection) (time.Duration, err
 		return 0, connection.RpcError(err)
 	}
 
-	uptime, ok := res[0].(float64)
-	if !ok {
-		return 0, connection.UnexpectedDatatypeErr
+	var uptime float64
+	err = connection.Unpack(res, &uptime)
+	if err != nil {
+		return 0, err
 	}
 
-	return time.Duration(uptime) * time.Second, nil
+	return time.Duration(uptime * float64(time.Second)), nil
 }
 
-func Time(ctx context.Context, conn connection.Connection) (float64, error) {
+func Time(ctx context.Context, conn connection.Connection) (GameTime, error) {
 	res, err := conn.Execute(ctx, "os.time()")
 	if err != nil {
 		return 0, connection.RpcError(err)
 	}
 
-	tme, ok := res[0].(float64)
-	if !ok {
-		return 0, connection.RpcError(err)
+	var tme float64
+	err = connection.Unpack(res, &tme)
+	if err != nil {
+		return 0, err
 	}
 
-	return tme, nil
+	return GameTime(tme), nil
 }
 
 type computer struct {
+	*settings.Settings
 	conn connection.Connection
 }
 
+var _ Computer = (*computer)(nil)
+
 func NewComputer(conn connection.Connection) *computer {
 	c := new(computer)
 	c.conn = conn
+	c.Settings = settings.New(conn)
 	return c
 }
 
@@ -149,6 +157,6 @@ func (c *computer) Uptime(ctx context.Context) (uptime time.Duration, err error)
 	return Uptime(ctx, c.conn)
 }
 
-func (c *computer) Time(ctx context.Context) (t float64, err error) {
+func (c *computer) Time(ctx context.Context) (t GameTime, err error) {
 	return Time(ctx, c.conn)
 }
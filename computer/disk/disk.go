@@ -0,0 +1,230 @@
+package disk
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/m4schini/computercraft-go/computer/peripheral"
+	"github.com/m4schini/computercraft-go/connection"
+)
+
+const (
+	PeripheralType = "drive"
+
+	// InsertEvent is queued when a disk is inserted into a drive
+	InsertEvent = "disk"
+	// EjectEvent is queued when a disk is removed from a drive
+	EjectEvent = "disk_eject"
+)
+
+var (
+	ErrNoDisk = errors.New("no disk in drive")
+	ErrNoData = errors.New("disk has no data")
+)
+
+// Event is a disk being inserted into or removed from a drive
+type Event struct {
+	// Drive is the peripheral name of the drive
+	Drive    string
+	Inserted bool
+}
+
+// Drive is a wrapped disk drive.
+type Drive struct {
+	h *peripheral.Handle
+}
+
+// New wraps a peripheral handle as a disk drive.
+func New(h *peripheral.Handle) (*Drive, error) {
+	if !h.HasType(PeripheralType) {
+		return nil, fmt.Errorf("peripheral %v is not a %v", h.Name(), PeripheralType)
+	}
+	return &Drive{h: h}, nil
+}
+
+// Wrap returns the disk drive with the given name (or on the given side).
+func Wrap(ctx context.Context, conn connection.Connection, name string) (*Drive, error) {
+	h, err := peripheral.Wrap(ctx, conn, name)
+	if err != nil {
+		return nil, err
+	}
+	return New(h)
+}
+
+// Find returns all attached disk drives.
+func Find(ctx context.Context, conn connection.Connection) ([]*Drive, error) {
+	handles, err := peripheral.Find(ctx, conn, PeripheralType)
+	if err != nil {
+		return nil, err
+	}
+
+	drives := make([]*Drive, len(handles))
+	for i, h := range handles {
+		drives[i] = &Drive{h: h}
+	}
+	return drives, nil
+}
+
+// Watch returns a channel receiving all disk insertions and removals of all drives, until ctx is done.
+func Watch(ctx context.Context, conn connection.Connection) (<-chan Event, error) {
+	events, err := conn.Subscribe(ctx, InsertEvent, EjectEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make(chan Event)
+	go func() {
+		defer close(out)
+		for event := range events {
+			e := Event{Inserted: event.Name == InsertEvent}
+			if event.Arg(0, &e.Drive) != nil {
+				continue
+			}
+			select {
+			case out <- e:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return out, nil
+}
+
+// Name returns the peripheral name of the drive
+func (d *Drive) Name() string {
+	return d.h.Name()
+}
+
+// IsPresent checks if a disk (or any other item) is in the drive.
+func (d *Drive) IsPresent(ctx context.Context) (present bool, err error) {
+	res, err := d.h.Call(ctx, "isDiskPresent")
+	if err != nil {
+		return false, err
+	}
+	err = connection.Unpack(res, &present)
+	return present, err
+}
+
+// Label returns the label of the disk, or an empty string if it has none.
+func (d *Drive) Label(ctx context.Context) (label string, err error) {
+	res, err := d.h.Call(ctx, "getDiskLabel")
+	if err != nil {
+		return "", err
+	}
+	err = connection.Unpack(res, &label)
+	return label, err
+}
+
+// SetLabel sets the label of the disk. An empty label removes it.
+func (d *Drive) SetLabel(ctx context.Context, label string) error {
+	var arg any
+	if label != "" {
+		arg = label
+	}
+	_, err := d.h.Call(ctx, "setDiskLabel", arg)
+	return err
+}
+
+// HasData checks if the drive contains a disk with a filesystem (e.g. a floppy disk).
+func (d *Drive) HasData(ctx context.Context) (hasData bool, err error) {
+	res, err := d.h.Call(ctx, "hasData")
+	if err != nil {
+		return false, err
+	}
+	err = connection.Unpack(res, &hasData)
+	return hasData, err
+}
+
+// MountPath returns the path the disk is mounted to, e.g. "disk2".
+func (d *Drive) MountPath(ctx context.Context) (string, error) {
+	res, err := d.h.Call(ctx, "getMountPath")
+	if err != nil {
+		return "", err
+	}
+	if len(res) < 1 || res[0] == nil {
+		return "", fmt.Errorf("%w: %v", ErrNoData, d.Name())
+	}
+
+	var path string
+	err = connection.Unpack(res, &path)
+	return path, err
+}
+
+// ID returns the id of the floppy disk in the drive.
+func (d *Drive) ID(ctx context.Context) (int, error) {
+	res, err := d.h.Call(ctx, "getDiskID")
+	if err != nil {
+		return 0, err
+	}
+	if len(res) < 1 || res[0] == nil {
+		return 0, fmt.Errorf("%w: %v", ErrNoDisk, d.Name())
+	}
+
+	var id int
+	err = connection.Unpack(res, &id)
+	return id, err
+}
+
+// HasAudio checks if the drive contains a disk with audio (e.g. a music disc).
+func (d *Drive) HasAudio(ctx context.Context) (hasAudio bool, err error) {
+	res, err := d.h.Call(ctx, "hasAudio")
+	if err != nil {
+		return false, err
+	}
+	err = connection.Unpack(res, &hasAudio)
+	return hasAudio, err
+}
+
+// AudioTitle returns the title of the audio disc, or an empty string if there is none.
+func (d *Drive) AudioTitle(ctx context.Context) (title string, err error) {
+	res, err := d.h.Call(ctx, "getAudioTitle")
+	if err != nil {
+		return "", err
+	}
+	if len(res) < 1 || res[0] == false {
+		return "", nil
+	}
+	err = connection.Unpack(res, &title)
+	return title, err
+}
+
+// PlayAudio starts playing the audio disc.
+func (d *Drive) PlayAudio(ctx context.Context) error {
+	_, err := d.h.Call(ctx, "playAudio")
+	return err
+}
+
+// StopAudio stops playing the audio disc.
+func (d *Drive) StopAudio(ctx context.Context) error {
+	_, err := d.h.Call(ctx, "stopAudio")
+	return err
+}
+
+// Eject ejects the disk, it is dropped into the world.
+func (d *Drive) Eject(ctx context.Context) error {
+	_, err := d.h.Call(ctx, "ejectDisk")
+	return err
+}
+
+// duplicateFunc replaces the content of a disk with the content of another one and copies its label
+const duplicateFunc = `function(src, dst) local s, d = peripheral.wrap(src), peripheral.wrap(dst) local from, to = s.getMountPath(), d.getMountPath() if not from or not to then error("disk has no data", 0) end for _, name in ipairs(fs.list(to)) do fs.delete(fs.combine(to, name)) end for _, name in ipairs(fs.list(from)) do fs.copy(fs.combine(from, name), fs.combine(to, name)) end d.setDiskLabel(s.getDiskLabel()) end`
+
+// Duplicate replaces the content and the label of the disks in the target drives with the
+// content of the disk in the source drive. It stops at the first drive that fails.
+func Duplicate(ctx context.Context, conn connection.Connection, source *Drive, targets ...*Drive) error {
+	for _, target := range targets {
+		if target.Name() == source.Name() {
+			continue
+		}
+
+		arguments, err := connection.LuaArgs(source.Name(), target.Name())
+		if err != nil {
+			return err
+		}
+		_, err = conn.Execute(ctx, fmt.Sprintf("(%v)(%v)", duplicateFunc, arguments))
+		if err != nil {
+			return fmt.Errorf("duplicate to %v: %w", target.Name(), connection.RpcError(err))
+		}
+	}
+	return nil
+}
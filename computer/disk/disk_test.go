@@ -0,0 +1,76 @@
+package disk
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/test"
+	"strings"
+	"testing"
+)
+
+func drive(name string) map[string]any {
+	return test.Peripheral(name, []string{"drive"}, []string{"isDiskPresent", "getDiskLabel", "setDiskLabel", "hasData", "getMountPath", "getDiskID", "ejectDisk"})
+}
+
+func TestDrive_ID_noDisk(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{drive("left")}, []any{})
+	d, err := Wrap(context.TODO(), conn, "left")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//act
+	_, err = d.ID(context.TODO())
+
+	//assert
+	t.Logf("actual: %v", err)
+	if err == nil || !strings.Contains(err.Error(), ErrNoDisk.Error()) {
+		t.FailNow()
+	}
+}
+
+func TestDuplicate(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{[]any{drive("drive_0"), drive("drive_1"), drive("drive_2")}})
+	drives, err := Find(context.TODO(), conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Reset()
+
+	//act
+	err = Duplicate(context.TODO(), conn, drives[0], drives...)
+
+	//assert
+	commands := conn.Executed()
+	t.Logf("actual: %v", commands)
+	if err != nil || len(commands) != 2 {
+		t.FailNow()
+	}
+	if !strings.HasSuffix(commands[0], `("drive_0", "drive_1")`) || !strings.HasSuffix(commands[1], `("drive_0", "drive_2")`) {
+		t.FailNow()
+	}
+}
+
+func TestWatch(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	events, err := Watch(ctx, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//act
+	conn.Emit(EjectEvent, "drive_3")
+	event := <-events
+
+	//assert
+	t.Logf("actual: %+v", event)
+	if event.Drive != "drive_3" || event.Inserted {
+		t.FailNow()
+	}
+}
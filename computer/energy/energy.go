@@ -0,0 +1,115 @@
+package energy
+
+import (
+	"context"
+	"fmt"
+	"github.com/m4schini/computercraft-go/computer/peripheral"
+	"github.com/m4schini/computercraft-go/connection"
+	"github.com/m4schini/computercraft-go/sampler"
+	"time"
+)
+
+const (
+	PeripheralType = "energy_storage"
+)
+
+// Reading is the energy level of a storage at one point in time
+type Reading struct {
+	Energy   int
+	Capacity int
+}
+
+// Fraction returns how full the storage is, between 0 and 1
+func (r Reading) Fraction() float64 {
+	if r.Capacity <= 0 {
+		return 0
+	}
+	return float64(r.Energy) / float64(r.Capacity)
+}
+
+// Storage is a wrapped peripheral with the generic energy storage methods, e.g. an energy cell.
+type Storage struct {
+	h *peripheral.Handle
+}
+
+// New wraps a peripheral handle as an energy storage.
+func New(h *peripheral.Handle) (*Storage, error) {
+	if !h.HasType(PeripheralType) {
+		return nil, fmt.Errorf("peripheral %v is not an %v", h.Name(), PeripheralType)
+	}
+	return &Storage{h: h}, nil
+}
+
+// Wrap returns the energy storage with the given name (or on the given side).
+func Wrap(ctx context.Context, conn connection.Connection, name string) (*Storage, error) {
+	h, err := peripheral.Wrap(ctx, conn, name)
+	if err != nil {
+		return nil, err
+	}
+	return New(h)
+}
+
+// Find returns all attached energy storages.
+func Find(ctx context.Context, conn connection.Connection) ([]*Storage, error) {
+	handles, err := peripheral.Find(ctx, conn, PeripheralType)
+	if err != nil {
+		return nil, err
+	}
+
+	storages := make([]*Storage, len(handles))
+	for i, h := range handles {
+		storages[i] = &Storage{h: h}
+	}
+	return storages, nil
+}
+
+// Name returns the peripheral name of the storage
+func (s *Storage) Name() string {
+	return s.h.Name()
+}
+
+// Energy gets the energy of this block, usually in FE.
+func (s *Storage) Energy(ctx context.Context) (energy int, err error) {
+	res, err := s.h.Call(ctx, "getEnergy")
+	if err != nil {
+		return 0, err
+	}
+	err = connection.Unpack(res, &energy)
+	return energy, err
+}
+
+// Capacity gets the maximum amount of energy this block can hold.
+func (s *Storage) Capacity(ctx context.Context) (capacity int, err error) {
+	res, err := s.h.Call(ctx, "getEnergyCapacity")
+	if err != nil {
+		return 0, err
+	}
+	err = connection.Unpack(res, &capacity)
+	return capacity, err
+}
+
+// Read gets the energy and the capacity of this block in a single round trip.
+func (s *Storage) Read(ctx context.Context) (r Reading, err error) {
+	results, err := s.h.CallBatch(ctx,
+		peripheral.Invocation{Method: "getEnergy"},
+		peripheral.Invocation{Method: "getEnergyCapacity"})
+	if err != nil {
+		return Reading{}, err
+	}
+	err = connection.Unpack(results[0], &r.Energy)
+	if err != nil {
+		return Reading{}, err
+	}
+	err = connection.Unpack(results[1], &r.Capacity)
+	return r, err
+}
+
+// Sample reads the storage every interval until ctx is done.
+func (s *Storage) Sample(ctx context.Context, interval time.Duration) <-chan sampler.Sample[Reading] {
+	return sampler.Poll(ctx, interval, s.Read)
+}
+
+// Low forwards the samples at which a storage drops below a fraction (0 to 1) of its capacity, until ctx is done.
+func Low(ctx context.Context, samples <-chan sampler.Sample[Reading], fraction float64) <-chan sampler.Sample[Reading] {
+	return sampler.Below(ctx, samples, fraction, Reading.Fraction)
+}
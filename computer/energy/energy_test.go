@@ -0,0 +1,71 @@
+package energy
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/sampler"
+	"github.com/m4schini/computercraft-go/test"
+	"strings"
+	"testing"
+)
+
+var cell = test.Peripheral("energy_cell_0", []string{"thermal:energy_cell", "energy_storage"}, []string{"getEnergy", "getEnergyCapacity"})
+
+func TestStorage_Read(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond(
+		[]any{cell},
+		[]any{[]any{[]any{float64(250)}, []any{float64(1000)}}},
+	)
+	s, err := Wrap(context.TODO(), conn, "energy_cell_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//act
+	reading, err := s.Read(context.TODO())
+
+	//assert
+	commands := conn.Executed()
+	t.Logf("actual: %+v %v", reading, commands)
+	if err != nil || reading.Energy != 250 || reading.Capacity != 1000 || reading.Fraction() != 0.25 {
+		t.FailNow()
+	}
+	if len(commands) != 2 || !strings.Contains(commands[1], `"getEnergy"`) || !strings.Contains(commands[1], `"getEnergyCapacity"`) {
+		t.FailNow()
+	}
+}
+
+func TestReading_Fraction_noCapacity(t *testing.T) {
+	//act
+	fraction := Reading{Energy: 10}.Fraction()
+
+	//assert
+	if fraction != 0 {
+		t.Logf("actual: %v", fraction)
+		t.FailNow()
+	}
+}
+
+func TestLow(t *testing.T) {
+	//arrange
+	samples := make(chan sampler.Sample[Reading])
+	go func() {
+		for _, energy := range []int{900, 100, 50, 800, 10} {
+			samples <- sampler.Sample[Reading]{Value: Reading{Energy: energy, Capacity: 1000}}
+		}
+		close(samples)
+	}()
+
+	//act
+	var actual []int
+	for alert := range Low(context.TODO(), samples, 0.2) {
+		actual = append(actual, alert.Value.Energy)
+	}
+
+	//assert
+	t.Logf("actual: %v", actual)
+	if len(actual) != 2 || actual[0] != 100 || actual[1] != 10 {
+		t.FailNow()
+	}
+}
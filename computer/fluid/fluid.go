@@ -0,0 +1,148 @@
+package fluid
+
+import (
+	"context"
+	"fmt"
+	"github.com/m4schini/computercraft-go/computer/peripheral"
+	"github.com/m4schini/computercraft-go/connection"
+	"github.com/m4schini/computercraft-go/sampler"
+	"time"
+)
+
+const (
+	PeripheralType = "fluid_storage"
+)
+
+// Tank is the content of a single tank. Amounts are in millibuckets.
+type Tank struct {
+	Name   string `lua:"name"`
+	Amount int    `lua:"amount"`
+}
+
+// Empty checks if the tank contains no fluid
+func (t Tank) Empty() bool {
+	return t.Name == "" || t.Amount == 0
+}
+
+// Storage is a wrapped peripheral with the generic fluid storage methods, e.g. a tank.
+type Storage struct {
+	h *peripheral.Handle
+}
+
+// New wraps a peripheral handle as a fluid storage.
+func New(h *peripheral.Handle) (*Storage, error) {
+	if !h.HasType(PeripheralType) {
+		return nil, fmt.Errorf("peripheral %v is not a %v", h.Name(), PeripheralType)
+	}
+	return &Storage{h: h}, nil
+}
+
+// Wrap returns the fluid storage with the given name (or on the given side).
+func Wrap(ctx context.Context, conn connection.Connection, name string) (*Storage, error) {
+	h, err := peripheral.Wrap(ctx, conn, name)
+	if err != nil {
+		return nil, err
+	}
+	return New(h)
+}
+
+// Find returns all attached fluid storages.
+func Find(ctx context.Context, conn connection.Connection) ([]*Storage, error) {
+	handles, err := peripheral.Find(ctx, conn, PeripheralType)
+	if err != nil {
+		return nil, err
+	}
+
+	storages := make([]*Storage, len(handles))
+	for i, h := range handles {
+		storages[i] = &Storage{h: h}
+	}
+	return storages, nil
+}
+
+// Name returns the peripheral name of the storage
+func (s *Storage) Name() string {
+	return s.h.Name()
+}
+
+// Tanks gets all tanks of this block. Empty tanks are included as empty Tank values.
+func (s *Storage) Tanks(ctx context.Context) ([]Tank, error) {
+	res, err := s.h.Call(ctx, "tanks")
+	if err != nil {
+		return nil, err
+	}
+
+	var tanks []Tank
+	err = connection.Unpack(res, &tanks)
+	return tanks, err
+}
+
+// Amount returns the total amount of a fluid in all tanks of this block.
+func (s *Storage) Amount(ctx context.Context, fluid string) (int, error) {
+	tanks, err := s.Tanks(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	amount := 0
+	for _, tank := range tanks {
+		if tank.Name == fluid {
+			amount += tank.Amount
+		}
+	}
+	return amount, nil
+}
+
+// PushFluid moves fluid from this block into another one on the same wired network
+// and returns the moved amount. A limit of 0 moves as much as possible, an empty
+// fluid name moves any fluid.
+func (s *Storage) PushFluid(ctx context.Context, toName string, limit int, fluid string) (moved int, err error) {
+	res, err := s.h.Call(ctx, "pushFluid", transferArgs(toName, limit, fluid)...)
+	if err != nil {
+		return 0, err
+	}
+	err = connection.Unpack(res, &moved)
+	return moved, err
+}
+
+// PullFluid moves fluid from another block on the same wired network into this one
+// and returns the moved amount. A limit of 0 moves as much as possible, an empty
+// fluid name moves any fluid.
+func (s *Storage) PullFluid(ctx context.Context, fromName string, limit int, fluid string) (moved int, err error) {
+	res, err := s.h.Call(ctx, "pullFluid", transferArgs(fromName, limit, fluid)...)
+	if err != nil {
+		return 0, err
+	}
+	err = connection.Unpack(res, &moved)
+	return moved, err
+}
+
+func transferArgs(name string, limit int, fluid string) []any {
+	args := []any{name, nil, nil}
+	if limit > 0 {
+		args[1] = limit
+	}
+	if fluid != "" {
+		args[2] = fluid
+	}
+	return args
+}
+
+// Sample reads the tanks every interval until ctx is done.
+func (s *Storage) Sample(ctx context.Context, interval time.Duration) <-chan sampler.Sample[[]Tank] {
+	return sampler.Poll(ctx, interval, s.Tanks)
+}
+
+// Low forwards the samples at which the total amount of a fluid drops below a
+// threshold (in millibuckets), until ctx is done.
+func Low(ctx context.Context, samples <-chan sampler.Sample[[]Tank], fluid string, threshold int) <-chan sampler.Sample[[]Tank] {
+	return sampler.Below(ctx, samples, float64(threshold), func(tanks []Tank) float64 {
+		amount := 0
+		for _, tank := range tanks {
+			if tank.Name == fluid {
+				amount += tank.Amount
+			}
+		}
+		return float64(amount)
+	})
+}
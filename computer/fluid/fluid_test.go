@@ -0,0 +1,102 @@
+package fluid
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/sampler"
+	"github.com/m4schini/computercraft-go/test"
+	"strings"
+	"testing"
+)
+
+var tank = test.Peripheral("tank_0", []string{"create:fluid_tank", "fluid_storage"}, []string{"tanks", "pushFluid", "pullFluid"})
+
+func wrapTank(t *testing.T, conn *test.ConnectionMock) *Storage {
+	conn.Respond([]any{tank})
+	s, err := Wrap(context.TODO(), conn, "tank_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Reset()
+	return s
+}
+
+func TestStorage_Amount(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	s := wrapTank(t, conn)
+	conn.Respond([]any{[]any{
+		map[string]any{"name": "minecraft:water", "amount": float64(1000)},
+		map[string]any{},
+		map[string]any{"name": "minecraft:water", "amount": float64(500)},
+	}})
+
+	//act
+	amount, err := s.Amount(context.TODO(), "minecraft:water")
+
+	//assert
+	t.Logf("actual: %v", amount)
+	if err != nil || amount != 1500 {
+		t.FailNow()
+	}
+}
+
+func TestStorage_PushFluid(t *testing.T) {
+	tests := []struct {
+		name     string
+		limit    int
+		fluid    string
+		expected string
+	}{
+		{name: "any", expected: `"pushFluid", "tank_1", nil, nil)`},
+		{name: "limit", limit: 250, expected: `"pushFluid", "tank_1", 250, nil)`},
+		{name: "fluid", fluid: "minecraft:lava", expected: `"pushFluid", "tank_1", nil, "minecraft:lava")`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//arrange
+			conn := &test.ConnectionMock{}
+			s := wrapTank(t, conn)
+			conn.Respond([]any{float64(100)})
+
+			//act
+			moved, err := s.PushFluid(context.TODO(), "tank_1", tt.limit, tt.fluid)
+
+			//assert
+			commands := conn.Executed()
+			t.Logf("actual: %v %v", moved, commands)
+			if err != nil || moved != 100 || !strings.HasSuffix(commands[0], tt.expected) {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestTank_Empty(t *testing.T) {
+	//assert
+	if !(Tank{}).Empty() || !(Tank{Name: "minecraft:water"}).Empty() || (Tank{Name: "minecraft:water", Amount: 1}).Empty() {
+		t.FailNow()
+	}
+}
+
+func TestLow(t *testing.T) {
+	//arrange
+	samples := make(chan sampler.Sample[[]Tank])
+	go func() {
+		for _, amount := range []int{2000, 800, 300, 1500} {
+			samples <- sampler.Sample[[]Tank]{Value: []Tank{{Name: "minecraft:water", Amount: amount}, {Name: "minecraft:lava", Amount: 5000}}}
+		}
+		close(samples)
+	}()
+
+	//act
+	var actual []int
+	for alert := range Low(context.TODO(), samples, "minecraft:water", 1000) {
+		actual = append(actual, alert.Value[0].Amount)
+	}
+
+	//assert
+	t.Logf("actual: %v", actual)
+	if len(actual) != 1 || actual[0] != 800 {
+		t.FailNow()
+	}
+}
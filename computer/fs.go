@@ -1,6 +1,22 @@
 package computer
 
-import "context"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/m4schini/computercraft-go/connection"
+	"io"
+	"time"
+)
+
+const (
+	FsModuleName = "fs"
+
+	// ReadChunkSize is the number of bytes read per round trip by ReadFile
+	ReadChunkSize = 16 * 1024
+	// WriteChunkSize is the number of bytes written per round trip by WriteFile
+	WriteChunkSize = 16 * 1024
+)
 
 type FileSystem interface {
 	//IsDriveRoot returns true if a path is mounted to the parent filesystem. The root filesystem "/" is
@@ -13,4 +29,290 @@ type FileSystem interface {
 
 	//List returns a list of files in a directory.
 	List(ctx context.Context, path string) ([]string, error)
+
+	//Exists checks whether the given path exists.
+	Exists(ctx context.Context, path string) (bool, error)
+	//IsDir checks whether the given path is a directory.
+	IsDir(ctx context.Context, path string) (bool, error)
+	//IsReadOnly checks whether a path is read-only.
+	IsReadOnly(ctx context.Context, path string) (bool, error)
+	//Drive returns the name of the mount that the specified path is located on.
+	Drive(ctx context.Context, path string) (string, error)
+
+	//MakeDir creates a directory, and any missing parents, at the specified path.
+	MakeDir(ctx context.Context, path string) error
+	//Move moves a file or directory from one path to another. Any parent directories are created as needed.
+	Move(ctx context.Context, from, to string) error
+	//Copy copies a file or directory to a new path. Any parent directories are created as needed.
+	Copy(ctx context.Context, from, to string) error
+	//Delete deletes a file or directory. Directories are deleted recursively.
+	Delete(ctx context.Context, path string) error
+
+	//Size returns the size of the specified file in bytes.
+	Size(ctx context.Context, path string) (int64, error)
+	//FreeSpace returns the number of bytes available on the drive the path is located on.
+	FreeSpace(ctx context.Context, path string) (int64, error)
+	//Capacity returns the capacity of the drive the path is located on, or -1 for read-only drives.
+	Capacity(ctx context.Context, path string) (int64, error)
+	//Attributes returns attributes about a specific file or directory.
+	Attributes(ctx context.Context, path string) (FileAttributes, error)
+	//Find searches for files matching a string with wildcards (*, ?).
+	Find(ctx context.Context, pattern string) ([]string, error)
+
+	//ReadFile reads the content of a file into w and returns the number of bytes read.
+	ReadFile(ctx context.Context, path string, w io.Writer) (int64, error)
+	//WriteFile writes the content of r into a file, replacing it, and returns the number of bytes written.
+	WriteFile(ctx context.Context, path string, r io.Reader) (int64, error)
+	//Checksums returns the crc32 (IEEE) checksums of all writable files below dir, keyed by their path
+	//relative to dir. Read-only files and other drives are skipped. Requires the ccgo runtime.
+	Checksums(ctx context.Context, dir string) (map[string]uint32, error)
+}
+
+// FileAttributes are the attributes of a file or directory, see fs.attributes
+type FileAttributes struct {
+	Size       int64
+	IsDir      bool
+	IsReadOnly bool
+	Created    time.Time
+	Modified   time.Time
+}
+
+type fileAttributes struct {
+	Size         int64 `lua:"size"`
+	IsDir        bool  `lua:"isDir"`
+	IsReadOnly   bool  `lua:"isReadOnly"`
+	Created      int64 `lua:"created"`
+	Modification int64 `lua:"modification"`
+	// Name is only set by the io/fs adapter
+	Name string `lua:"name"`
+}
+
+func (a fileAttributes) convert() FileAttributes {
+	return FileAttributes{
+		Size:       a.Size,
+		IsDir:      a.IsDir,
+		IsReadOnly: a.IsReadOnly,
+		Created:    time.UnixMilli(a.Created),
+		Modified:   time.UnixMilli(a.Modification),
+	}
+}
+
+type fileSystem struct {
+	conn connection.Connection
+}
+
+// NewFileSystem returns the file system of a computer (or turtle)
+func NewFileSystem(conn connection.Connection) FileSystem {
+	return &fileSystem{conn: conn}
+}
+
+func (f *fileSystem) call(ctx context.Context, function string, args ...any) ([]any, error) {
+	arguments, err := connection.LuaArgs(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := f.conn.Execute(ctx, fmt.Sprintf("%v.%v(%v)", FsModuleName, function, arguments))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+	return res, nil
+}
+
+func (f *fileSystem) callInto(ctx context.Context, out any, function string, args ...any) error {
+	res, err := f.call(ctx, function, args...)
+	if err != nil {
+		return err
+	}
+	if len(res) < 1 {
+		return connection.RpcError(errors.New("unexpected data length"))
+	}
+	return connection.Decode(res[0], out)
+}
+
+func (f *fileSystem) IsDriveRoot(ctx context.Context, path string) (root bool, err error) {
+	err = f.callInto(ctx, &root, "isDriveRoot", path)
+	return root, err
+}
+
+func (f *fileSystem) Complete(ctx context.Context, path, location string, includeFiles, includeDirs bool) (completions []string, err error) {
+	err = f.callInto(ctx, &completions, "complete", path, location, includeFiles, includeDirs)
+	return completions, err
+}
+
+func (f *fileSystem) List(ctx context.Context, path string) (files []string, err error) {
+	err = f.callInto(ctx, &files, "list", path)
+	return files, err
+}
+
+func (f *fileSystem) Exists(ctx context.Context, path string) (exists bool, err error) {
+	err = f.callInto(ctx, &exists, "exists", path)
+	return exists, err
+}
+
+func (f *fileSystem) IsDir(ctx context.Context, path string) (isDir bool, err error) {
+	err = f.callInto(ctx, &isDir, "isDir", path)
+	return isDir, err
+}
+
+func (f *fileSystem) IsReadOnly(ctx context.Context, path string) (readOnly bool, err error) {
+	err = f.callInto(ctx, &readOnly, "isReadOnly", path)
+	return readOnly, err
+}
+
+func (f *fileSystem) Drive(ctx context.Context, path string) (drive string, err error) {
+	err = f.callInto(ctx, &drive, "getDrive", path)
+	return drive, err
+}
+
+func (f *fileSystem) MakeDir(ctx context.Context, path string) error {
+	_, err := f.call(ctx, "makeDir", path)
+	return err
+}
+
+func (f *fileSystem) Move(ctx context.Context, from, to string) error {
+	_, err := f.call(ctx, "move", from, to)
+	return err
+}
+
+func (f *fileSystem) Copy(ctx context.Context, from, to string) error {
+	_, err := f.call(ctx, "copy", from, to)
+	return err
+}
+
+func (f *fileSystem) Delete(ctx context.Context, path string) error {
+	_, err := f.call(ctx, "delete", path)
+	return err
+}
+
+func (f *fileSystem) Size(ctx context.Context, path string) (size int64, err error) {
+	err = f.callInto(ctx, &size, "getSize", path)
+	return size, err
+}
+
+func (f *fileSystem) FreeSpace(ctx context.Context, path string) (space int64, err error) {
+	// unlimited drives report "unlimited" instead of a number
+	res, err := f.call(ctx, "getFreeSpace", path)
+	if err != nil {
+		return 0, err
+	}
+	if len(res) > 0 && res[0] == "unlimited" {
+		return -1, nil
+	}
+	err = connection.Unpack(res, &space)
+	return space, err
+}
+
+func (f *fileSystem) Capacity(ctx context.Context, path string) (capacity int64, err error) {
+	res, err := f.call(ctx, "getCapacity", path)
+	if err != nil {
+		return 0, err
+	}
+	if len(res) < 1 || res[0] == nil {
+		return -1, nil
+	}
+	err = connection.Unpack(res, &capacity)
+	return capacity, err
+}
+
+func (f *fileSystem) Attributes(ctx context.Context, path string) (FileAttributes, error) {
+	var attributes fileAttributes
+	err := f.callInto(ctx, &attributes, "attributes", path)
+	if err != nil {
+		return FileAttributes{}, err
+	}
+
+	return attributes.convert(), nil
+}
+
+func (f *fileSystem) Find(ctx context.Context, pattern string) (files []string, err error) {
+	err = f.callInto(ctx, &files, "find", pattern)
+	return files, err
+}
+
+// readChunkFunc reads up to n bytes of a file starting at offset. It returns nil at the end of the file.
+const readChunkFunc = `function(path, offset, n) local f, err = fs.open(path, "rb") if not f then error(err, 0) end f.seek("set", offset) local data = f.read(n) f.close() return data end`
+
+// writeChunkFunc writes data to a file, either replacing ("wb") or appending to it ("ab").
+const writeChunkFunc = `function(path, mode, data) local f, err = fs.open(path, mode) if not f then error(err, 0) end f.write(data) f.close() return #data end`
+
+func (f *fileSystem) ReadFile(ctx context.Context, path string, w io.Writer) (int64, error) {
+	var offset int64
+	for {
+		arguments, err := connection.LuaArgs(path, offset, ReadChunkSize)
+		if err != nil {
+			return offset, err
+		}
+
+		res, err := f.conn.Execute(ctx, fmt.Sprintf("(%v)(%v)", readChunkFunc, arguments))
+		if err != nil {
+			return offset, connection.RpcError(err)
+		}
+
+		var chunk []byte
+		err = connection.Unpack(res, &chunk)
+		if err != nil {
+			return offset, err
+		}
+		if len(chunk) == 0 {
+			return offset, nil
+		}
+
+		n, err := w.Write(chunk)
+		offset += int64(n)
+		if err != nil {
+			return offset, err
+		}
+		if len(chunk) < ReadChunkSize {
+			return offset, nil
+		}
+	}
+}
+
+func (f *fileSystem) WriteFile(ctx context.Context, path string, r io.Reader) (int64, error) {
+	var written int64
+	mode := "wb"
+	buf := make([]byte, WriteChunkSize)
+	for {
+		n, readErr := io.ReadFull(r, buf)
+		if readErr != nil && readErr != io.EOF && readErr != io.ErrUnexpectedEOF {
+			return written, readErr
+		}
+
+		// the first chunk is always written, so empty files are created as well
+		if n > 0 || mode == "wb" {
+			arguments, err := connection.LuaArgs(path, mode, buf[:n])
+			if err != nil {
+				return written, err
+			}
+
+			_, err = f.conn.Execute(ctx, fmt.Sprintf("(%v)(%v)", writeChunkFunc, arguments))
+			if err != nil {
+				return written, connection.RpcError(err)
+			}
+			written += int64(n)
+			mode = "ab"
+		}
+
+		if readErr != nil {
+			return written, nil
+		}
+	}
+}
+
+func (f *fileSystem) Checksums(ctx context.Context, dir string) (map[string]uint32, error) {
+	res, err := f.conn.Execute(ctx, fmt.Sprintf("%v.checksums(%v)", connection.RuntimeModuleName, connection.LuaString(dir)))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+
+	var checksums map[string]uint32
+	err = connection.Unpack(res, &checksums)
+	if err != nil {
+		return nil, err
+	}
+	if checksums == nil {
+		checksums = make(map[string]uint32)
+	}
+	return checksums, nil
 }
@@ -0,0 +1,63 @@
+package computer
+
+import (
+	"bytes"
+	"context"
+	"github.com/m4schini/computercraft-go/test"
+	"strings"
+	"testing"
+)
+
+func TestFileSystem_ReadFile(t *testing.T) {
+	//arrange
+	var expected = append(bytes.Repeat([]byte{0xff}, ReadChunkSize), 0x00, 'a')
+	conn := &test.ConnectionMock{}
+	conn.Respond(
+		[]any{string(expected[:ReadChunkSize])},
+		[]any{string(expected[ReadChunkSize:])},
+	)
+	var buf bytes.Buffer
+
+	//act
+	n, err := NewFileSystem(conn).ReadFile(context.TODO(), "startup.lua", &buf)
+
+	//assert
+	t.Logf("expected: %v bytes", len(expected))
+	t.Logf("  actual: %v bytes (%v commands)", n, len(conn.Executed()))
+	if err != nil || n != int64(len(expected)) || !bytes.Equal(buf.Bytes(), expected) || len(conn.Executed()) != 2 {
+		t.FailNow()
+	}
+}
+
+func TestFileSystem_WriteFile(t *testing.T) {
+	//arrange
+	content := strings.Repeat("x", WriteChunkSize) + "\n"
+	conn := &test.ConnectionMock{}
+
+	//act
+	n, err := NewFileSystem(conn).WriteFile(context.TODO(), "startup.lua", strings.NewReader(content))
+
+	//assert
+	commands := conn.Executed()
+	t.Logf("actual: %v bytes (%v commands)", n, len(commands))
+	if err != nil || n != int64(len(content)) || len(commands) != 2 {
+		t.FailNow()
+	}
+	if !strings.HasSuffix(commands[0], `("startup.lua", "wb", "`+strings.Repeat("x", WriteChunkSize)+`")`) ||
+		!strings.HasSuffix(commands[1], `("startup.lua", "ab", "\n")`) {
+		t.FailNow()
+	}
+}
+
+func TestFileSystem_WriteFile_empty(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+
+	//act
+	_, err := NewFileSystem(conn).WriteFile(context.TODO(), "empty", strings.NewReader(""))
+
+	//assert
+	if err != nil || len(conn.Executed()) != 1 || !strings.HasSuffix(conn.Executed()[0], `("empty", "wb", "")`) {
+		t.FailNow()
+	}
+}
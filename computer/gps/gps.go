@@ -118,3 +118,50 @@ func (r *relativeGPS) Locate(ctx context.Context) (int, int, int, error) {
 func (r *relativeGPS) LocateWithTimeout(ctx context.Context, timeout time.Duration) (int, int, int, error) {
 	return r.x, r.y, r.z, nil
 }
+
+// Position is a position in the world
+type Position struct {
+	X, Y, Z int
+}
+
+func (p Position) String() string {
+	return fmt.Sprintf("%v, %v, %v", p.X, p.Y, p.Z)
+}
+
+// DefaultTrackInterval is used by Track for intervals that are not positive.
+const DefaultTrackInterval = time.Second
+
+// Track locates g every interval and sends its position, whenever it changed. Failed
+// locates (e.g. out of range of the gps hosts) are skipped. The channel is closed when ctx is done.
+// If interval is not positive, DefaultTrackInterval is used.
+func Track(ctx context.Context, g GPS, interval time.Duration) <-chan Position {
+	if interval <= 0 {
+		interval = DefaultTrackInterval
+	}
+	positions := make(chan Position)
+	go func() {
+		defer close(positions)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		var last *Position
+		for {
+			x, y, z, err := g.Locate(ctx)
+			if err == nil && (last == nil || *last != Position{x, y, z}) {
+				last = &Position{x, y, z}
+				select {
+				case positions <- *last:
+				case <-ctx.Done():
+					return
+				}
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return positions
+}
@@ -0,0 +1,70 @@
+package gps
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// fakeGPS returns the queued positions, nil entries fail
+type fakeGPS struct {
+	positions []*Position
+}
+
+func (f *fakeGPS) Locate(ctx context.Context) (int, int, int, error) {
+	return f.LocateWithTimeout(ctx, DefaultTimeout)
+}
+
+func (f *fakeGPS) LocateWithTimeout(ctx context.Context, timeout time.Duration) (int, int, int, error) {
+	if len(f.positions) == 0 {
+		return 0, 0, 0, errors.New("no more positions")
+	}
+	p := f.positions[0]
+	f.positions = f.positions[1:]
+	if p == nil {
+		return 0, 0, 0, errors.New("position could not be established")
+	}
+	return p.X, p.Y, p.Z, nil
+}
+
+func TestTrack(t *testing.T) {
+	//arrange
+	a, b := &Position{1, 64, 1}, &Position{2, 64, 1}
+	g := &fakeGPS{positions: []*Position{a, a, nil, b, a}}
+	var expected = []Position{*a, *b, *a}
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	//act
+	positions := Track(ctx, g, time.Millisecond)
+	var actual []Position
+	for len(actual) < len(expected) {
+		actual = append(actual, <-positions)
+	}
+
+	//assert
+	if !reflect.DeepEqual(expected, actual) {
+		t.Logf("expected: %v", expected)
+		t.Logf("actual  : %v", actual)
+		t.FailNow()
+	}
+}
+
+func TestTrack_noInterval(t *testing.T) {
+	//arrange
+	g := &fakeGPS{positions: []*Position{{1, 64, 1}}}
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	//act
+	positions := Track(ctx, g, 0)
+	actual := <-positions
+
+	//assert
+	if actual != (Position{1, 64, 1}) {
+		t.Logf("actual: %v", actual)
+		t.FailNow()
+	}
+}
@@ -0,0 +1,155 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/m4schini/computercraft-go/connection"
+	nethttp "net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	ModuleName = "http"
+)
+
+// Request is a http request performed by a computer, see http.request
+type Request struct {
+	// Method defaults to GET, or POST if the request has a body
+	Method string
+	URL    string
+	Header nethttp.Header
+	Body   []byte
+	// Timeout is the timeout of the request on the computer. The default of computercraft is used if it is zero.
+	Timeout time.Duration
+}
+
+// Response is the response of a http request. Responses with an error status code
+// (e.g. 404) are returned as responses as well, like net/http does.
+type Response struct {
+	StatusCode int
+	// Status is the status message, e.g. "OK"
+	Status string
+	Header nethttp.Header
+	Body   []byte
+}
+
+type response struct {
+	Code    int               `lua:"code"`
+	Status  string            `lua:"status"`
+	Headers map[string]string `lua:"headers"`
+	Body    []byte            `lua:"body"`
+}
+
+func (r response) convert() *Response {
+	header := make(nethttp.Header, len(r.Headers))
+	for key, value := range r.Headers {
+		header.Set(key, value)
+	}
+	return &Response{
+		StatusCode: r.Code,
+		Status:     r.Status,
+		Header:     header,
+		Body:       r.Body,
+	}
+}
+
+// requestFunc performs a request and returns the response as a table. Failed requests with a response
+// (e.g. 404) are returned as well.
+const requestFunc = `function(req) local f = req.body and http.post or http.get local r, err, fail = f(req) r = r or fail if not r then error(err, 0) end local code, status = r.getResponseCode() local res = {code = code, status = status, headers = r.getResponseHeaders(), body = r.readAll()} r.close() return res end`
+
+// table returns the request as the lua table expected by http.request
+func (r Request) table() map[string]any {
+	t := map[string]any{
+		"url":    r.URL,
+		"binary": true,
+	}
+	if r.Method != "" {
+		t["method"] = strings.ToUpper(r.Method)
+	}
+	if len(r.Header) > 0 {
+		t["headers"] = joinHeader(r.Header)
+	}
+	if r.Body != nil {
+		t["body"] = r.Body
+	}
+	if r.Timeout > 0 {
+		t["timeout"] = r.Timeout.Seconds()
+	}
+	return t
+}
+
+// joinHeader converts a header into a lua table. Computercraft supports only one value per key,
+// so multiple values are joined.
+func joinHeader(header nethttp.Header) map[string]string {
+	keys := make([]string, 0, len(header))
+	for key := range header {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	joined := make(map[string]string, len(header))
+	for _, key := range keys {
+		joined[key] = strings.Join(header[key], ", ")
+	}
+	return joined
+}
+
+// Do performs a http request on the computer. The http api has to be enabled in the computercraft config
+// and the computer has to be allowed to reach the url.
+func Do(ctx context.Context, conn connection.Connection, req Request) (*Response, error) {
+	if req.URL == "" {
+		return nil, errors.New("request has no url")
+	}
+
+	arguments, err := connection.LuaValue(req.table())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := conn.Execute(ctx, fmt.Sprintf("(%v)(%v)", requestFunc, arguments))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+
+	var r response
+	err = connection.Unpack(res, &r)
+	if err != nil {
+		return nil, err
+	}
+	return r.convert(), nil
+}
+
+// Get performs a GET request on the computer
+func Get(ctx context.Context, conn connection.Connection, url string, header nethttp.Header) (*Response, error) {
+	return Do(ctx, conn, Request{Method: nethttp.MethodGet, URL: url, Header: header})
+}
+
+// Post performs a POST request on the computer
+func Post(ctx context.Context, conn connection.Connection, url string, header nethttp.Header, body []byte) (*Response, error) {
+	if body == nil {
+		body = []byte{}
+	}
+	return Do(ctx, conn, Request{Method: nethttp.MethodPost, URL: url, Header: header, Body: body})
+}
+
+// CheckURL checks if the computer is allowed to reach the url. It returns an error with the reason, if it is not.
+func CheckURL(ctx context.Context, conn connection.Connection, url string) error {
+	res, err := conn.Execute(ctx, fmt.Sprintf("%v.checkURL(%v)", ModuleName, connection.LuaString(url)))
+	if err != nil {
+		return connection.RpcError(err)
+	}
+
+	var ok bool
+	var reason string
+	err = connection.Unpack(res, &ok, &reason)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return errors.New(reason)
+	}
+	return nil
+}
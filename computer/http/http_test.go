@@ -0,0 +1,111 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"github.com/m4schini/computercraft-go/test"
+	nethttp "net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDo(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{map[string]any{
+		"code":    float64(404),
+		"status":  "Not Found",
+		"headers": map[string]any{"Content-Type": "text/plain"},
+		"body":    "missing",
+	}})
+
+	//act
+	res, err := Get(context.TODO(), conn, "http://base.local/items", nethttp.Header{"Accept": {"text/plain"}})
+
+	//assert
+	t.Logf("actual: %v %+v", conn.Executed()[0], res)
+	if err != nil || !strings.HasSuffix(conn.Executed()[0], `({["binary"] = true, ["headers"] = {["Accept"] = "text/plain"}, ["method"] = "GET", ["url"] = "http://base.local/items"})`) {
+		t.FailNow()
+	}
+	if res.StatusCode != 404 || res.Header.Get("content-type") != "text/plain" || string(res.Body) != "missing" {
+		t.FailNow()
+	}
+}
+
+func TestProxy_Serve(t *testing.T) {
+	//arrange
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.Write([]byte("pong"))
+	}))
+	defer server.Close()
+	conn := &test.ConnectionMock{}
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+	go NewProxy(conn, "127.0.0.1").Serve(ctx)
+	time.Sleep(10 * time.Millisecond)
+
+	//act
+	conn.Emit(ProxyRequestEvent, float64(1), map[string]any{"url": server.URL + "/ping"})
+	conn.Emit(ProxyRequestEvent, float64(2), map[string]any{"url": "https://example.com"})
+	for len(conn.Executed()) < 2 && ctx.Err() == nil {
+		time.Sleep(time.Millisecond)
+	}
+
+	//assert
+	commands := strings.Join(conn.Executed(), "\n")
+	t.Logf("actual: %v", commands)
+	if !strings.Contains(commands, `os.queueEvent("ccgo_http_response", 1, {["code"] = 200, ["status"] = "OK"`) ||
+		!strings.Contains(commands, `["body"] = "pong"})`) ||
+		!strings.Contains(commands, `os.queueEvent("ccgo_http_response", 2, nil, "host is not allowed: https://example.com")`) {
+		t.FailNow()
+	}
+}
+
+func TestProxy_redirectNotAllowed(t *testing.T) {
+	//arrange
+	hidden := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.Write([]byte("secret"))
+	}))
+	defer hidden.Close()
+	target := strings.Replace(hidden.URL, "127.0.0.1", "localhost", 1)
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		nethttp.Redirect(w, r, target, nethttp.StatusFound)
+	}))
+	defer server.Close()
+	p := NewProxy(&test.ConnectionMock{}, "127.0.0.1")
+
+	//act
+	res, err := p.do(context.TODO(), proxyRequest{URL: server.URL})
+
+	//assert
+	t.Logf("actual: %v %v", res, err)
+	if res != nil || !errors.Is(err, ErrHostNotAllowed) {
+		t.FailNow()
+	}
+}
+
+func TestProxy_redirectNotAllowed_customClient(t *testing.T) {
+	//arrange
+	hidden := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		w.Write([]byte("secret"))
+	}))
+	defer hidden.Close()
+	target := strings.Replace(hidden.URL, "127.0.0.1", "localhost", 1)
+	server := httptest.NewServer(nethttp.HandlerFunc(func(w nethttp.ResponseWriter, r *nethttp.Request) {
+		nethttp.Redirect(w, r, target, nethttp.StatusFound)
+	}))
+	defer server.Close()
+	p := NewProxy(&test.ConnectionMock{}, "127.0.0.1")
+	p.Client = &nethttp.Client{}
+
+	//act
+	res, err := p.do(context.TODO(), proxyRequest{URL: server.URL})
+
+	//assert
+	t.Logf("actual: %v %v", res, err)
+	if res != nil || !errors.Is(err, ErrHostNotAllowed) {
+		t.FailNow()
+	}
+}
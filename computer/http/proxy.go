@@ -0,0 +1,173 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/m4schini/computercraft-go/connection"
+	"io"
+	nethttp "net/http"
+	"net/url"
+	"path"
+	"time"
+)
+
+const (
+	// ProxyRequestEvent is emitted by ccgo.fetch with the id and the request table
+	ProxyRequestEvent = "ccgo_http_request"
+	// ProxyResponseEvent is queued on the computer with the id and the response table, or nil and an error message
+	ProxyResponseEvent = "ccgo_http_response"
+
+	// MaxProxyBodySize limits the size of response bodies sent to the computer,
+	// so they fit into a single websocket message
+	MaxProxyBodySize = 24 * 1024
+	// ProxyTimeout is the default timeout of proxied requests
+	ProxyTimeout = 30 * time.Second
+)
+
+var ErrHostNotAllowed = errors.New("host is not allowed")
+
+// Proxy performs http requests for a computer, that are made with ccgo.fetch in the lua runtime.
+// Only hosts of the allow-list can be reached.
+type Proxy struct {
+	// Client performs the requests, it defaults to a client with ProxyTimeout. Redirects
+	// are always checked against the allow-list, regardless of the CheckRedirect of the client.
+	Client *nethttp.Client
+
+	conn  connection.Connection
+	allow []string
+}
+
+type proxyRequest struct {
+	URL     string            `lua:"url"`
+	Method  string            `lua:"method"`
+	Headers map[string]string `lua:"headers"`
+	Body    *string           `lua:"body"`
+}
+
+// NewProxy returns a proxy for a computer, that allows requests to hosts matching
+// one of the patterns (see path.Match), e.g. "api.example.com" or "*.example.com".
+func NewProxy(conn connection.Connection, allow ...string) *Proxy {
+	return &Proxy{
+		Client: &nethttp.Client{Timeout: ProxyTimeout},
+		conn:   conn,
+		allow:  allow,
+	}
+}
+
+// maxRedirects is the number of redirects followed by the default client, like the default of net/http
+const maxRedirects = 10
+
+// client returns a copy of Client, that only follows redirects to allowed hosts, so
+// the allow-list can not be bypassed with a redirect
+func (p *Proxy) client() *nethttp.Client {
+	client := *p.Client
+	checkRedirect := client.CheckRedirect
+	client.CheckRedirect = func(req *nethttp.Request, via []*nethttp.Request) error {
+		if !p.Allowed(req.URL.String()) {
+			return fmt.Errorf("%w: %v", ErrHostNotAllowed, req.URL)
+		}
+		if checkRedirect != nil {
+			return checkRedirect(req, via)
+		}
+		if len(via) >= maxRedirects {
+			return fmt.Errorf("stopped after %v redirects", maxRedirects)
+		}
+		return nil
+	}
+	return &client
+}
+
+// Allowed checks if a url can be reached through the proxy
+func (p *Proxy) Allowed(rawURL string) bool {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return false
+	}
+	for _, pattern := range p.allow {
+		if ok, _ := path.Match(pattern, u.Hostname()); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// Serve handles requests of the computer until ctx is done. Every request is handled in its own goroutine.
+func (p *Proxy) Serve(ctx context.Context) error {
+	events, err := p.conn.Subscribe(ctx, ProxyRequestEvent)
+	if err != nil {
+		return err
+	}
+
+	for event := range events {
+		var id int
+		var req proxyRequest
+		if event.Arg(0, &id) != nil || event.Arg(1, &req) != nil {
+			continue
+		}
+		go p.handle(ctx, id, req)
+	}
+	return ctx.Err()
+}
+
+func (p *Proxy) handle(ctx context.Context, id int, req proxyRequest) {
+	res, err := p.do(ctx, req)
+
+	var arguments string
+	if err == nil {
+		arguments, err = connection.LuaArgs(ProxyResponseEvent, id, res)
+	}
+	if err != nil {
+		// the computer always gets a reply, so ccgo.fetch does not wait until it times out
+		arguments = fmt.Sprintf("%v, %v, nil, %v", connection.LuaString(ProxyResponseEvent), id, connection.LuaString(err.Error()))
+	}
+	_, _ = p.conn.Execute(ctx, fmt.Sprintf("os.queueEvent(%v)", arguments))
+}
+
+func (p *Proxy) do(ctx context.Context, req proxyRequest) (*response, error) {
+	if !p.Allowed(req.URL) {
+		return nil, fmt.Errorf("%w: %v", ErrHostNotAllowed, req.URL)
+	}
+
+	method := req.Method
+	var body io.Reader
+	if req.Body != nil {
+		body = bytes.NewReader([]byte(*req.Body))
+		if method == "" {
+			method = nethttp.MethodPost
+		}
+	}
+	if method == "" {
+		method = nethttp.MethodGet
+	}
+
+	r, err := nethttp.NewRequestWithContext(ctx, method, req.URL, body)
+	if err != nil {
+		return nil, err
+	}
+	for key, value := range req.Headers {
+		r.Header.Set(key, value)
+	}
+
+	res, err := p.client().Do(r)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(res.Body, MaxProxyBodySize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > MaxProxyBodySize {
+		return nil, fmt.Errorf("response body exceeds %v bytes", MaxProxyBodySize)
+	}
+
+	return &response{
+		Code:    res.StatusCode,
+		Status:  nethttp.StatusText(res.StatusCode),
+		Headers: joinHeader(res.Header),
+		Body:    content,
+	}, nil
+}
@@ -0,0 +1,208 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"github.com/m4schini/computercraft-go/computer/peripheral"
+	"github.com/m4schini/computercraft-go/connection"
+	"sort"
+)
+
+const (
+	PeripheralType = "inventory"
+)
+
+// ItemStack is a stack of items, as returned by inventory.list.
+type ItemStack struct {
+	Name  string `lua:"name"`
+	Count int    `lua:"count"`
+	NBT   string `lua:"nbt"`
+}
+
+// Enchantment is an enchantment of an item.
+type Enchantment struct {
+	Name        string `lua:"name"`
+	Level       int    `lua:"level"`
+	DisplayName string `lua:"displayName"`
+}
+
+// ItemDetail is detailed information about a stack of items, as returned by
+// inventory.getItemDetail.
+type ItemDetail struct {
+	ItemStack
+	DisplayName  string          `lua:"displayName"`
+	MaxCount     int             `lua:"maxCount"`
+	Damage       int             `lua:"damage"`
+	MaxDamage    int             `lua:"maxDamage"`
+	Durability   float64         `lua:"durability"`
+	Tags         map[string]bool `lua:"tags"`
+	Enchantments []Enchantment   `lua:"enchantments"`
+}
+
+// Inventory is a wrapped peripheral with the generic inventory methods, e.g. a chest.
+type Inventory struct {
+	h *peripheral.Handle
+}
+
+// New wraps a peripheral handle as an inventory.
+func New(h *peripheral.Handle) (*Inventory, error) {
+	if !h.HasType(PeripheralType) {
+		return nil, fmt.Errorf("peripheral %v is not an %v", h.Name(), PeripheralType)
+	}
+	return &Inventory{h: h}, nil
+}
+
+// Wrap returns the inventory with the given name (or on the given side).
+func Wrap(ctx context.Context, conn connection.Connection, name string) (*Inventory, error) {
+	h, err := peripheral.Wrap(ctx, conn, name)
+	if err != nil {
+		return nil, err
+	}
+	return New(h)
+}
+
+// Find returns all attached inventories.
+func Find(ctx context.Context, conn connection.Connection) ([]*Inventory, error) {
+	handles, err := peripheral.Find(ctx, conn, PeripheralType)
+	if err != nil {
+		return nil, err
+	}
+
+	inventories := make([]*Inventory, len(handles))
+	for i, h := range handles {
+		inventories[i] = &Inventory{h: h}
+	}
+	return inventories, nil
+}
+
+// Name returns the peripheral name of the inventory
+func (i *Inventory) Name() string {
+	return i.h.Name()
+}
+
+// Size gets the number of slots of the inventory.
+func (i *Inventory) Size(ctx context.Context) (size int, err error) {
+	res, err := i.h.Call(ctx, "size")
+	if err != nil {
+		return 0, err
+	}
+	err = connection.Unpack(res, &size)
+	return size, err
+}
+
+// List lists all items in the inventory by slot. Empty slots are omitted.
+func (i *Inventory) List(ctx context.Context) (map[int]ItemStack, error) {
+	res, err := i.h.Call(ctx, "list")
+	if err != nil {
+		return nil, err
+	}
+
+	items := make(map[int]ItemStack)
+	err = connection.Unpack(res, &items)
+	return items, err
+}
+
+// ItemDetail gets detailed information about the item in the given slot. It
+// returns nil if the slot is empty.
+func (i *Inventory) ItemDetail(ctx context.Context, slot int) (*ItemDetail, error) {
+	res, err := i.h.Call(ctx, "getItemDetail", slot)
+	if err != nil {
+		return nil, err
+	}
+
+	var detail *ItemDetail
+	err = connection.Unpack(res, &detail)
+	return detail, err
+}
+
+// ItemLimit gets the maximum number of items which can be stored in the given slot.
+func (i *Inventory) ItemLimit(ctx context.Context, slot int) (limit int, err error) {
+	res, err := i.h.Call(ctx, "getItemLimit", slot)
+	if err != nil {
+		return 0, err
+	}
+	err = connection.Unpack(res, &limit)
+	return limit, err
+}
+
+// PushItems pushes items from a slot of this inventory into another inventory on the
+// same wired network and returns the number of transferred items. A limit of 0
+// transfers the whole stack, a toSlot of 0 lets the target inventory choose the slot.
+func (i *Inventory) PushItems(ctx context.Context, toName string, fromSlot, limit, toSlot int) (transferred int, err error) {
+	res, err := i.h.Call(ctx, "pushItems", transferArgs(toName, fromSlot, limit, toSlot)...)
+	if err != nil {
+		return 0, err
+	}
+	err = connection.Unpack(res, &transferred)
+	return transferred, err
+}
+
+// PullItems pulls items from a slot of another inventory on the same wired network
+// into this inventory and returns the number of transferred items. A limit of 0
+// transfers the whole stack, a toSlot of 0 lets this inventory choose the slot.
+func (i *Inventory) PullItems(ctx context.Context, fromName string, fromSlot, limit, toSlot int) (transferred int, err error) {
+	res, err := i.h.Call(ctx, "pullItems", transferArgs(fromName, fromSlot, limit, toSlot)...)
+	if err != nil {
+		return 0, err
+	}
+	err = connection.Unpack(res, &transferred)
+	return transferred, err
+}
+
+func transferArgs(name string, fromSlot, limit, toSlot int) []any {
+	args := []any{name, fromSlot, nil, nil}
+	if limit > 0 {
+		args[2] = limit
+	}
+	if toSlot > 0 {
+		args[3] = toSlot
+	}
+	return args
+}
+
+// Move moves up to count items with the given name from this inventory into another
+// inventory on the same wired network. A count of 0 moves all matching items. It
+// returns the number of moved items, which is less than count if there weren't
+// enough items or the target inventory is full.
+func (i *Inventory) Move(ctx context.Context, toName, item string, count int) (int, error) {
+	items, err := i.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	slots := make([]int, 0, len(items))
+	for slot, stack := range items {
+		if stack.Name == item {
+			slots = append(slots, slot)
+		}
+	}
+	sort.Ints(slots)
+
+	moved := 0
+	for _, slot := range slots {
+		limit := items[slot].Count
+		if count > 0 && count-moved < limit {
+			limit = count - moved
+		}
+
+		transferred, err := i.PushItems(ctx, toName, slot, limit, 0)
+		moved += transferred
+		if err != nil {
+			return moved, err
+		}
+		if transferred < limit || (count > 0 && moved >= count) {
+			break
+		}
+	}
+	return moved, nil
+}
+
+// Move moves up to count items with the given name between two inventories on the
+// same wired network. See Inventory.Move.
+func Move(ctx context.Context, conn connection.Connection, fromName, toName, item string, count int) (int, error) {
+	from, err := Wrap(ctx, conn, fromName)
+	if err != nil {
+		return 0, err
+	}
+	return from.Move(ctx, toName, item, count)
+}
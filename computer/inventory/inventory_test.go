@@ -0,0 +1,63 @@
+package inventory
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/test"
+	"strings"
+	"testing"
+)
+
+var chest = test.Peripheral("minecraft:chest_0", []string{"minecraft:chest", "inventory"}, []string{"size", "list", "getItemDetail", "getItemLimit", "pushItems", "pullItems"})
+
+func TestInventory_ItemDetail(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond(
+		[]any{chest},
+		[]any{map[string]any{
+			"name": "minecraft:diamond_pickaxe", "count": float64(1), "displayName": "Diamond Pickaxe",
+			"maxCount": float64(1), "damage": float64(12), "tags": map[string]any{"minecraft:pickaxes": true},
+		}},
+	)
+	inv, err := Wrap(context.TODO(), conn, "minecraft:chest_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//act
+	detail, err := inv.ItemDetail(context.TODO(), 1)
+
+	//assert
+	t.Logf("actual: %+v", detail)
+	if err != nil || detail.Name != "minecraft:diamond_pickaxe" || detail.Damage != 12 || !detail.Tags["minecraft:pickaxes"] {
+		t.FailNow()
+	}
+}
+
+func TestMove(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond(
+		[]any{chest},
+		[]any{map[string]any{
+			"2": map[string]any{"name": "minecraft:cobblestone", "count": float64(64)},
+			"5": map[string]any{"name": "minecraft:dirt", "count": float64(10)},
+			"9": map[string]any{"name": "minecraft:cobblestone", "count": float64(64)},
+		}},
+		[]any{float64(64)},
+		[]any{float64(36)},
+	)
+
+	//act
+	moved, err := Move(context.TODO(), conn, "minecraft:chest_0", "minecraft:chest_1", "minecraft:cobblestone", 100)
+
+	//assert
+	commands := conn.Executed()
+	t.Logf("commands: %v", commands)
+	if err != nil || moved != 100 || len(commands) != 4 {
+		t.FailNow()
+	}
+	if !strings.HasSuffix(commands[2], `"minecraft:chest_1", 2, 64, nil)`) || !strings.HasSuffix(commands[3], `"minecraft:chest_1", 9, 36, nil)`) {
+		t.FailNow()
+	}
+}
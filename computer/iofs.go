@@ -0,0 +1,409 @@
+package computer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"github.com/m4schini/computercraft-go/connection"
+	"io"
+	"io/fs"
+	"sort"
+	"syscall"
+	"time"
+)
+
+// WritableFS is a fs.FS that can be modified. Directories are created as needed
+// by WriteFile and Rename, like the fs api of computercraft does.
+type WritableFS interface {
+	fs.ReadDirFS
+	fs.ReadFileFS
+	fs.StatFS
+
+	// WriteFile writes data to the named file, replacing it. The permissions are ignored.
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+	// MkdirAll creates a directory, along with any missing parents. The permissions are ignored.
+	MkdirAll(name string, perm fs.FileMode) error
+	// Remove removes the named file or empty directory.
+	Remove(name string) error
+	// RemoveAll removes the named file or directory, including its content.
+	RemoveAll(name string) error
+	// Rename moves a file or directory from one name to another.
+	Rename(oldName, newName string) error
+}
+
+// FS returns the filesystem of a computer as a fs.FS, so it can be used with
+// fs.WalkDir, http.FS, template.ParseFS etc. All operations use context.Background.
+func FS(conn connection.Connection) WritableFS {
+	return FSContext(context.Background(), conn)
+}
+
+// FSContext is like FS, but all operations use ctx.
+func FSContext(ctx context.Context, conn connection.Connection) WritableFS {
+	return &deviceFS{
+		ctx:  ctx,
+		conn: conn,
+		fs:   NewFileSystem(conn),
+	}
+}
+
+// statFunc returns the attributes of a file, or nil if it does not exist
+const statFunc = `function(path) if not fs.exists(path) then return nil end local a = fs.attributes(path) a.name = fs.getName(path) return a end`
+
+// readDirFunc returns the attributes of all entries of a directory, nil if it does not exist or false if it is a file
+const readDirFunc = `function(path) if not fs.exists(path) then return nil end if not fs.isDir(path) then return false end local r = {} for _, name in ipairs(fs.list(path)) do local a = fs.attributes(fs.combine(path, name)) a.name = name r[#r + 1] = a end return r end`
+
+type deviceFS struct {
+	ctx  context.Context
+	conn connection.Connection
+	fs   FileSystem
+}
+
+// devicePath converts a fs.FS path into an absolute path of the computer
+func devicePath(op, name string) (string, error) {
+	if !fs.ValidPath(name) {
+		return "", &fs.PathError{Op: op, Path: name, Err: fs.ErrInvalid}
+	}
+	if name == "." {
+		return "/", nil
+	}
+	return "/" + name, nil
+}
+
+func (d *deviceFS) stat(op, name string) (*fileInfo, error) {
+	p, err := devicePath(op, name)
+	if err != nil {
+		return nil, err
+	}
+
+	arguments, err := connection.LuaArgs(p)
+	if err != nil {
+		return nil, err
+	}
+	res, err := d.conn.Execute(d.ctx, fmt.Sprintf("(%v)(%v)", statFunc, arguments))
+	if err != nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: connection.RpcError(err)}
+	}
+	if len(res) < 1 || res[0] == nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: fs.ErrNotExist}
+	}
+
+	var attributes fileAttributes
+	err = connection.Decode(res[0], &attributes)
+	if err != nil {
+		return nil, &fs.PathError{Op: op, Path: name, Err: err}
+	}
+	if name == "." {
+		attributes.Name = "."
+	}
+	return newFileInfo(attributes), nil
+}
+
+func (d *deviceFS) Open(name string) (fs.File, error) {
+	info, err := d.stat("open", name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return &dir{fsys: d, name: name, info: info}, nil
+	}
+	return &file{fsys: d, name: name, info: info}, nil
+}
+
+func (d *deviceFS) Stat(name string) (fs.FileInfo, error) {
+	return d.stat("stat", name)
+}
+
+func (d *deviceFS) ReadDir(name string) ([]fs.DirEntry, error) {
+	p, err := devicePath("readdir", name)
+	if err != nil {
+		return nil, err
+	}
+
+	arguments, err := connection.LuaArgs(p)
+	if err != nil {
+		return nil, err
+	}
+	res, err := d.conn.Execute(d.ctx, fmt.Sprintf("(%v)(%v)", readDirFunc, arguments))
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: connection.RpcError(err)}
+	}
+	if len(res) < 1 || res[0] == nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: fs.ErrNotExist}
+	}
+	if isDir, ok := res[0].(bool); ok && !isDir {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: syscall.ENOTDIR}
+	}
+
+	var attributes []fileAttributes
+	err = connection.Decode(res[0], &attributes)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readdir", Path: name, Err: err}
+	}
+
+	entries := make([]fs.DirEntry, len(attributes))
+	for i, a := range attributes {
+		entries[i] = fs.FileInfoToDirEntry(newFileInfo(a))
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name() < entries[j].Name()
+	})
+	return entries, nil
+}
+
+func (d *deviceFS) ReadFile(name string) ([]byte, error) {
+	info, err := d.stat("readfile", name)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: errors.New("is a directory")}
+	}
+
+	p, err := devicePath("readfile", name)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(int(info.Size()))
+	_, err = d.fs.ReadFile(d.ctx, p, &buf)
+	if err != nil {
+		return nil, &fs.PathError{Op: "readfile", Path: name, Err: err}
+	}
+	return buf.Bytes(), nil
+}
+
+func (d *deviceFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	p, err := devicePath("writefile", name)
+	if err != nil {
+		return err
+	}
+	_, err = d.fs.WriteFile(d.ctx, p, bytes.NewReader(data))
+	if err != nil {
+		return &fs.PathError{Op: "writefile", Path: name, Err: err}
+	}
+	return nil
+}
+
+func (d *deviceFS) MkdirAll(name string, perm fs.FileMode) error {
+	p, err := devicePath("mkdir", name)
+	if err != nil {
+		return err
+	}
+	err = d.fs.MakeDir(d.ctx, p)
+	if err != nil {
+		return &fs.PathError{Op: "mkdir", Path: name, Err: err}
+	}
+	return nil
+}
+
+func (d *deviceFS) Remove(name string) error {
+	info, err := d.stat("remove", name)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		entries, err := d.ReadDir(name)
+		if err != nil {
+			return err
+		}
+		if len(entries) > 0 {
+			return &fs.PathError{Op: "remove", Path: name, Err: errors.New("directory not empty")}
+		}
+	}
+	return d.RemoveAll(name)
+}
+
+func (d *deviceFS) RemoveAll(name string) error {
+	p, err := devicePath("remove", name)
+	if err != nil {
+		return err
+	}
+	err = d.fs.Delete(d.ctx, p)
+	if err != nil {
+		return &fs.PathError{Op: "remove", Path: name, Err: err}
+	}
+	return nil
+}
+
+func (d *deviceFS) Rename(oldName, newName string) error {
+	from, err := devicePath("rename", oldName)
+	if err != nil {
+		return err
+	}
+	to, err := devicePath("rename", newName)
+	if err != nil {
+		return err
+	}
+	err = d.fs.Move(d.ctx, from, to)
+	if err != nil {
+		return &fs.PathError{Op: "rename", Path: oldName, Err: err}
+	}
+	return nil
+}
+
+// fileInfo implements fs.FileInfo for the attributes of a file
+type fileInfo struct {
+	name       string
+	attributes FileAttributes
+}
+
+func newFileInfo(a fileAttributes) *fileInfo {
+	return &fileInfo{
+		name:       a.Name,
+		attributes: a.convert(),
+	}
+}
+
+func (i *fileInfo) Name() string {
+	return i.name
+}
+
+func (i *fileInfo) Size() int64 {
+	return i.attributes.Size
+}
+
+func (i *fileInfo) Mode() fs.FileMode {
+	var mode fs.FileMode = 0644
+	if i.attributes.IsDir {
+		mode = fs.ModeDir | 0755
+	}
+	if i.attributes.IsReadOnly {
+		mode &^= 0222
+	}
+	return mode
+}
+
+func (i *fileInfo) ModTime() time.Time {
+	return i.attributes.Modified
+}
+
+func (i *fileInfo) IsDir() bool {
+	return i.attributes.IsDir
+}
+
+// Sys returns the FileAttributes of the file
+func (i *fileInfo) Sys() any {
+	return i.attributes
+}
+
+// file is an open file. Its content is read when it is first accessed.
+type file struct {
+	fsys   *deviceFS
+	name   string
+	info   *fileInfo
+	reader *bytes.Reader
+	closed bool
+}
+
+func (f *file) load(op string) error {
+	if f.closed {
+		return &fs.PathError{Op: op, Path: f.name, Err: fs.ErrClosed}
+	}
+	if f.reader != nil {
+		return nil
+	}
+	content, err := f.fsys.ReadFile(f.name)
+	if err != nil {
+		return err
+	}
+	f.reader = bytes.NewReader(content)
+	return nil
+}
+
+func (f *file) Stat() (fs.FileInfo, error) {
+	return f.info, nil
+}
+
+func (f *file) Read(p []byte) (int, error) {
+	err := f.load("read")
+	if err != nil {
+		return 0, err
+	}
+	return f.reader.Read(p)
+}
+
+func (f *file) ReadAt(p []byte, off int64) (int, error) {
+	err := f.load("read")
+	if err != nil {
+		return 0, err
+	}
+	return f.reader.ReadAt(p, off)
+}
+
+func (f *file) Seek(offset int64, whence int) (int64, error) {
+	err := f.load("seek")
+	if err != nil {
+		return 0, err
+	}
+	return f.reader.Seek(offset, whence)
+}
+
+func (f *file) Close() error {
+	if f.closed {
+		return &fs.PathError{Op: "close", Path: f.name, Err: fs.ErrClosed}
+	}
+	f.closed = true
+	return nil
+}
+
+// dir is an open directory. Its entries are read when they are first accessed.
+type dir struct {
+	fsys    *deviceFS
+	name    string
+	info    *fileInfo
+	entries []fs.DirEntry
+	loaded  bool
+	closed  bool
+}
+
+func (d *dir) Stat() (fs.FileInfo, error) {
+	return d.info, nil
+}
+
+func (d *dir) Read([]byte) (int, error) {
+	return 0, &fs.PathError{Op: "read", Path: d.name, Err: errors.New("is a directory")}
+}
+
+func (d *dir) ReadDir(n int) ([]fs.DirEntry, error) {
+	if d.closed {
+		return nil, &fs.PathError{Op: "readdir", Path: d.name, Err: fs.ErrClosed}
+	}
+	if !d.loaded {
+		entries, err := d.fsys.ReadDir(d.name)
+		if err != nil {
+			return nil, err
+		}
+		d.entries, d.loaded = entries, true
+	}
+
+	if n <= 0 {
+		entries := d.entries
+		d.entries = nil
+		return entries, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	if n > len(d.entries) {
+		n = len(d.entries)
+	}
+	entries := d.entries[:n]
+	d.entries = d.entries[n:]
+	return entries, nil
+}
+
+func (d *dir) Close() error {
+	if d.closed {
+		return &fs.PathError{Op: "close", Path: d.name, Err: fs.ErrClosed}
+	}
+	d.closed = true
+	return nil
+}
+
+var (
+	_ fs.ReadDirFile = (*dir)(nil)
+	_ io.ReadSeeker  = (*file)(nil)
+	_ io.ReaderAt    = (*file)(nil)
+)
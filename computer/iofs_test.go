@@ -0,0 +1,172 @@
+package computer
+
+import (
+	"errors"
+	"fmt"
+	"github.com/m4schini/computercraft-go/test"
+	"io/fs"
+	"path"
+	"reflect"
+	"regexp"
+	"strings"
+	"syscall"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFS_WalkDir(t *testing.T) {
+	//arrange
+	var expected = []string{".", "programs", "programs/mine.lua", "startup.lua"}
+	conn := &test.ConnectionMock{}
+	conn.Respond(
+		[]any{map[string]any{"name": "", "isDir": true, "size": float64(0)}},
+		[]any{[]any{
+			map[string]any{"name": "startup.lua", "isDir": false, "size": float64(120)},
+			map[string]any{"name": "programs", "isDir": true, "size": float64(0)},
+		}},
+		[]any{[]any{
+			map[string]any{"name": "mine.lua", "isDir": false, "size": float64(64), "isReadOnly": true},
+		}},
+	)
+	var actual []string
+
+	//act
+	err := fs.WalkDir(FS(conn), ".", func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		actual = append(actual, path)
+		return nil
+	})
+
+	//assert
+	t.Logf("expected: %v", expected)
+	t.Logf("  actual: %v", actual)
+	if err != nil || !reflect.DeepEqual(expected, actual) {
+		t.FailNow()
+	}
+}
+
+func TestFS_Stat_notExist(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{nil})
+
+	//act
+	_, err := FS(conn).Stat("missing.lua")
+
+	//assert
+	t.Logf("actual: %v", err)
+	if !errors.Is(err, fs.ErrNotExist) {
+		t.FailNow()
+	}
+}
+
+func TestFS_Open_invalid(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+
+	//act
+	_, err := FS(conn).Open("/startup.lua")
+
+	//assert
+	if !errors.Is(err, fs.ErrInvalid) || len(conn.Executed()) != 0 {
+		t.FailNow()
+	}
+}
+
+// fakeFS answers the commands of the io/fs adapter like the fs api of a computer with the given files
+func fakeFS(files map[string]string) func(command string) []any {
+	call := regexp.MustCompile(`^\((.*)\)\("([^"]*)"`)
+	attributes := func(p string) map[string]any {
+		if content, ok := files[strings.TrimPrefix(p, "/")]; ok {
+			return map[string]any{"name": path.Base(p), "isDir": false, "size": float64(len(content))}
+		}
+		prefix := strings.TrimPrefix(p, "/") + "/"
+		for name := range files {
+			if p == "/" || strings.HasPrefix(name, prefix) {
+				return map[string]any{"name": path.Base(p), "isDir": true, "size": float64(0)}
+			}
+		}
+		return nil
+	}
+
+	return func(command string) []any {
+		match := call.FindStringSubmatch(command)
+		if match == nil {
+			return []any{}
+		}
+		function, p := match[1], match[2]
+		switch function {
+		case statFunc:
+			if a := attributes(p); a != nil {
+				return []any{a}
+			}
+			return []any{nil}
+		case readDirFunc:
+			a := attributes(p)
+			if a == nil {
+				return []any{nil}
+			}
+			if a["isDir"] == false {
+				return []any{false}
+			}
+			entries := map[string]map[string]any{}
+			for name := range files {
+				rel := strings.TrimPrefix("/"+name, strings.TrimSuffix(p, "/")+"/")
+				if rel == "/"+name {
+					continue
+				}
+				child := strings.SplitN(rel, "/", 2)[0]
+				entries[child] = attributes(strings.TrimSuffix(p, "/") + "/" + child)
+			}
+			var res []any
+			for _, e := range entries {
+				res = append(res, e)
+			}
+			return []any{res}
+		case readChunkFunc:
+			content := files[strings.TrimPrefix(p, "/")]
+			var offset int
+			fmt.Sscanf(command[len(match[0]):], ", %d", &offset)
+			if offset >= len(content) {
+				return []any{""}
+			}
+			return []any{content[offset:]}
+		}
+		return []any{}
+	}
+}
+
+func TestFS_fstest(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{Handler: fakeFS(map[string]string{
+		"startup.lua":          "shell.run(\"mine\")",
+		"programs/mine.lua":    "turtle.dig()",
+		"programs/lib/inv.lua": "return {}",
+	})}
+
+	//act
+	err := fstest.TestFS(FS(conn), "startup.lua", "programs/mine.lua", "programs/lib/inv.lua")
+
+	//assert
+	if err != nil {
+		t.Log(err)
+		t.FailNow()
+	}
+}
+
+func TestFS_ReadDir_errors(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{Handler: fakeFS(map[string]string{"startup.lua": ""})}
+
+	//act
+	_, missing := FS(conn).ReadDir("programs")
+	_, file := FS(conn).ReadDir("startup.lua")
+
+	//assert
+	t.Logf("actual: %v, %v", missing, file)
+	if !errors.Is(missing, fs.ErrNotExist) || !errors.Is(file, syscall.ENOTDIR) {
+		t.FailNow()
+	}
+}
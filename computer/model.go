@@ -2,6 +2,7 @@ package computer
 
 import (
 	"context"
+	"github.com/m4schini/computercraft-go/computer/gps"
 	"time"
 )
 
@@ -35,10 +36,43 @@ type Computer interface {
 	SetComputerLabel(ctx context.Context, label string) error
 
 	Uptime(ctx context.Context) (time.Duration, error)
-	Time(ctx context.Context) (float64, error)
+	Time(ctx context.Context) (GameTime, error)
+
+	Settings
+}
+
+// PocketUpgrade is the peripheral type of the upgrade equipped on the back of a pocket computer
+type PocketUpgrade string
+
+const (
+	PocketUpgradeNone    PocketUpgrade = ""
+	PocketUpgradeModem   PocketUpgrade = "modem"
+	PocketUpgradeSpeaker PocketUpgrade = "speaker"
+)
+
+type Pocket interface {
+	Computer
+	// Locate and LocateWithTimeout need a wireless modem upgrade
+	gps.GPS
+
+	// EquipBack equips the upgrade in the selected slot of the player, the previous upgrade is moved into the inventory.
+	EquipBack(ctx context.Context) (bool, error)
+	// UnequipBack moves the equipped upgrade into the inventory of the player.
+	UnequipBack(ctx context.Context) (bool, error)
+	// Upgrade returns the equipped upgrade, or PocketUpgradeNone.
+	Upgrade(ctx context.Context) (PocketUpgrade, error)
+	// IsWireless checks if a wireless (or ender) modem is equipped.
+	IsWireless(ctx context.Context) (bool, error)
+	// Track locates the pocket computer (and the player carrying it) every interval, see gps.Track.
+	// Intervals that are not positive are replaced with gps.DefaultTrackInterval.
+	Track(ctx context.Context, interval time.Duration) <-chan gps.Position
 }
 
+// Turtle is a turtle. Actions, that fail, return false and a *connection.ActionError with
+// the reason. Known reasons match the errors of the turtle API (e.g. ErrMovementObstructed) with errors.Is.
 type Turtle interface {
+	Computer
+
 	// Forward moves the turtle forward one block.
 	Forward(ctx context.Context) (bool, error)
 	// Back moves the turtle backwards one block.
@@ -52,44 +86,47 @@ type Turtle interface {
 	// TurnRight rotates the turtle 90 degrees to the right.
 	TurnRight(ctx context.Context) (bool, error)
 
-	// Dig attempts to break the block in front of the turtle.
-	Dig(ctx context.Context) (bool, error)
+	// Dig attempts to break the block in front of the turtle. The side of the tool to use
+	//can be given, otherwise the tools on both sides are tried.
+	Dig(ctx context.Context, side ...Side) (bool, error)
 	// DigDown attempts to break the block below the turtle.
-	DigDown(ctx context.Context) (bool, error)
+	DigDown(ctx context.Context, side ...Side) (bool, error)
 	// DigUp attempts to break the block above the turtle.
-	DigUp(ctx context.Context) (bool, error)
+	DigUp(ctx context.Context, side ...Side) (bool, error)
 
-	// Place places a block or item into the world in front of the turtle.
-	Place(ctx context.Context) (bool, error)
+	// Place places a block or item into the world in front of the turtle. If a sign is
+	//placed, the text can be given.
+	Place(ctx context.Context, text ...string) (bool, error)
 	// PlaceUp places a block or item into the world above the turtle.
-	PlaceUp(ctx context.Context) (bool, error)
+	PlaceUp(ctx context.Context, text ...string) (bool, error)
 	// PlaceDown places a block or item into the world below the turtle.
-	PlaceDown(ctx context.Context) (bool, error)
+	PlaceDown(ctx context.Context, text ...string) (bool, error)
 
 	// Drop drops the currently selected stack into the inventory in front of
-	//the turtle, or as an item into the world if there is no inventory.
-	Drop(ctx context.Context, count int) (bool, error)
+	//the turtle, or as an item into the world if there is no inventory. Without
+	//a count, the whole stack is dropped.
+	Drop(ctx context.Context, count ...int) (bool, error)
 	// DropUp drops the currently selected stack into the inventory above the
 	//turtle, or as an item into the world if there is no inventory.
-	DropUp(ctx context.Context, count int) (bool, error)
+	DropUp(ctx context.Context, count ...int) (bool, error)
 	// DropDown drops the currently selected stack into the inventory in front
 	//of the turtle, or as an item into the world if there is no inventory.
-	DropDown(ctx context.Context, count int) (bool, error)
+	DropDown(ctx context.Context, count ...int) (bool, error)
 
 	// Select changes the currently selected slot.
 	Select(ctx context.Context, slot int) (bool, error)
 	// SelectedSlot gets the currently selected slot.
 	SelectedSlot(ctx context.Context) (int, error)
-	// ItemCount gets the number of items in the given slot.
-	ItemCount(ctx context.Context, slot int) (int, error)
+	// ItemCount gets the number of items in the given slot, or the selected slot.
+	ItemCount(ctx context.Context, slot ...int) (int, error)
 	// ItemSpace gets the remaining number of items which may be stored in this stack.
-	ItemSpace(ctx context.Context, slot int) (int, error)
-	// ItemDetail gets detailed information about the items in the given slot.
+	ItemSpace(ctx context.Context, slot ...int) (int, error)
+	// ItemDetail gets detailed information about the items in the given slot, or nil if it is empty.
 	ItemDetail(ctx context.Context, slot int, detailed bool) (map[string]interface{}, error)
 	// CompareTo compares the item in the currently selected slot to the item in another slot.
 	CompareTo(ctx context.Context, slot int) (bool, error)
 	// TransferTo moves an item from the selected slot to another one.
-	TransferTo(ctx context.Context, slot, count int) (bool, error)
+	TransferTo(ctx context.Context, slot int, count ...int) (bool, error)
 
 	// Detect checks if there is a solid block in front of the turtle. In this case,
 	//solid refers to any non-air or liquid block.
@@ -108,34 +145,47 @@ type Turtle interface {
 	// CompareDown checks if the block below the turtle is equal to the item in the currently selected slot.
 	CompareDown(ctx context.Context) (bool, error)
 
-	// Attack attacks the entity in front of the turtle.
-	Attack(ctx context.Context) (bool, error)
+	// Attack attacks the entity in front of the turtle. The side of the tool to use can be given.
+	Attack(ctx context.Context, side ...Side) (bool, error)
 	// AttackUp attacks the entity above the turtle.
-	AttackUp(ctx context.Context) (bool, error)
+	AttackUp(ctx context.Context, side ...Side) (bool, error)
 	// AttackDown attacks the entity below the turtle.
-	AttackDown(ctx context.Context) (bool, error)
+	AttackDown(ctx context.Context, side ...Side) (bool, error)
 
 	// Suck sucks an item from the inventory in front of the turtle, or from an item floating in the world.
-	Suck(ctx context.Context, count int) (bool, error)
+	Suck(ctx context.Context, count ...int) (bool, error)
 	// SuckUp sucks an item from the inventory above the turtle, or from an item floating in the world.
-	SuckUp(ctx context.Context, count int) (bool, error)
+	SuckUp(ctx context.Context, count ...int) (bool, error)
 	// SuckDown sucks an item from the inventory below the turtle, or from an item floating in the world.
-	SuckDown(ctx context.Context, count int) (bool, error)
+	SuckDown(ctx context.Context, count ...int) (bool, error)
 
-	// FuelLevel gets the maximum amount of fuel this turtle currently holds.
+	// FuelLevel gets the amount of fuel this turtle currently holds, or UnlimitedFuel.
 	FuelLevel(ctx context.Context) (int, error)
-	// Refuel refuels this turtle
-	Refuel(ctx context.Context, count int) (bool, error)
-	// FuelLimit gets the maximum amount of fuel this turtle can hold.
+	// Refuel refuels this turtle with the items in the selected slot. Without a count,
+	//the whole stack is consumed, a count of 0 only checks if the items are fuel.
+	Refuel(ctx context.Context, count ...int) (bool, error)
+	// FuelLimit gets the maximum amount of fuel this turtle can hold, or UnlimitedFuel.
 	FuelLimit(ctx context.Context) (int, error)
 
-	// Inspect gets information about the block in front of the turtle.
+	// Inspect gets information about the block in front of the turtle. If there is no block, false is returned.
 	Inspect(ctx context.Context) (bool, Block, error)
 	// InspectUp gets information about the block above the turtle.
 	InspectUp(ctx context.Context) (bool, Block, error)
 	// InspectDown gets information about the block below the turtle.
 	InspectDown(ctx context.Context) (bool, Block, error)
 
-	// Craft crafts a recipe based on the turtle's inventory.
-	Craft(ctx context.Context, limit int) (bool, error)
+	// Craft crafts a recipe based on the turtle's inventory. Without a limit, as many
+	//items as possible are crafted.
+	Craft(ctx context.Context, limit ...int) (bool, error)
+
+	// EquipLeft equips the item in the selected slot on the left side. The previous upgrade is moved into the selected slot.
+	EquipLeft(ctx context.Context) (bool, error)
+	// EquipRight equips the item in the selected slot on the right side. The previous upgrade is moved into the selected slot.
+	EquipRight(ctx context.Context) (bool, error)
+	// EquippedLeft gets the upgrade equipped on the left side, or UpgradeNone.
+	EquippedLeft(ctx context.Context) (TurtleUpgrade, error)
+	// EquippedRight gets the upgrade equipped on the right side, or UpgradeNone.
+	EquippedRight(ctx context.Context) (TurtleUpgrade, error)
+	// Upgrades gets the upgrades equipped on both sides.
+	Upgrades(ctx context.Context) (TurtleUpgrades, error)
 }
@@ -0,0 +1,170 @@
+package monitor
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/computer/colors"
+	"github.com/m4schini/computercraft-go/computer/peripheral"
+)
+
+// Cell is a single character on a monitor.
+type Cell struct {
+	Char       byte
+	Text       colors.Color
+	Background colors.Color
+}
+
+// Canvas is a buffered drawing surface for a monitor. Drawing only modifies the
+// buffer. Flush sends all rows that changed since the last flush in one command.
+// Coordinates start at 1, 1 in the top left corner, like on the monitor itself.
+type Canvas struct {
+	m             *Monitor
+	width, height int
+	cells         [][]Cell
+	sent          [][]Cell
+
+	// Text and Background are the colours used by Text and Clear
+	Text       colors.Color
+	Background colors.Color
+}
+
+// NewCanvas creates a canvas with the current size of the monitor.
+func NewCanvas(ctx context.Context, m *Monitor) (*Canvas, error) {
+	width, height, err := m.Size(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Canvas{m: m, Text: colors.White, Background: colors.Black}
+	c.Resize(width, height)
+	return c, nil
+}
+
+// Size returns the size of the canvas in characters.
+func (c *Canvas) Size() (width, height int) {
+	return c.width, c.height
+}
+
+// Resize changes the size of the canvas and clears it. The next flush redraws the
+// whole monitor. Call it after changing the text scale of the monitor.
+func (c *Canvas) Resize(width, height int) {
+	c.width, c.height = width, height
+	c.cells = make([][]Cell, height)
+	for y := range c.cells {
+		c.cells[y] = make([]Cell, width)
+	}
+	c.sent = nil
+	c.Clear()
+}
+
+// Clear fills the canvas with spaces in the background colour.
+func (c *Canvas) Clear() {
+	for y := 1; y <= c.height; y++ {
+		for x := 1; x <= c.width; x++ {
+			c.Set(x, y, Cell{Char: ' ', Text: c.Text, Background: c.Background})
+		}
+	}
+}
+
+// Set sets the cell at x, y. Cells outside the canvas are ignored.
+func (c *Canvas) Set(x, y int, cell Cell) {
+	if x < 1 || y < 1 || x > c.width || y > c.height {
+		return
+	}
+	c.cells[y-1][x-1] = cell
+}
+
+// Get returns the cell at x, y.
+func (c *Canvas) Get(x, y int) (Cell, bool) {
+	if x < 1 || y < 1 || x > c.width || y > c.height {
+		return Cell{}, false
+	}
+	return c.cells[y-1][x-1], true
+}
+
+// Write draws text starting at x, y in the current colours of the canvas. Text
+// exceeding the canvas is cut off.
+func (c *Canvas) Write(x, y int, text string) {
+	for i := 0; i < len(text); i++ {
+		c.Set(x+i, y, Cell{Char: text[i], Text: c.Text, Background: c.Background})
+	}
+}
+
+// Fill fills a rectangle with spaces in the given background colour.
+func (c *Canvas) Fill(x, y, width, height int, background colors.Color) {
+	for dy := 0; dy < height; dy++ {
+		for dx := 0; dx < width; dx++ {
+			c.Set(x+dx, y+dy, Cell{Char: ' ', Text: c.Text, Background: background})
+		}
+	}
+}
+
+// Invalidate forces the next flush to redraw every row.
+func (c *Canvas) Invalidate() {
+	c.sent = nil
+}
+
+// changedRows returns the (1 based) rows that differ from the last flushed frame.
+func (c *Canvas) changedRows() []int {
+	rows := make([]int, 0)
+	for y, row := range c.cells {
+		if c.sent == nil || !equalRow(row, c.sent[y]) {
+			rows = append(rows, y+1)
+		}
+	}
+	return rows
+}
+
+func equalRow(a, b []Cell) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// blitRow returns the blit arguments of a row
+func (c *Canvas) blitRow(y int) (text, fg, bg string) {
+	row := c.cells[y-1]
+	t, f, b := make([]byte, len(row)), make([]byte, len(row)), make([]byte, len(row))
+	for i, cell := range row {
+		t[i], f[i], b[i] = cell.Char, cell.Text.Blit(), cell.Background.Blit()
+		if t[i] == 0 {
+			t[i] = ' '
+		}
+	}
+	return string(t), string(f), string(b)
+}
+
+// Flush sends all changed rows to the monitor with a single command.
+func (c *Canvas) Flush(ctx context.Context) error {
+	rows := c.changedRows()
+	if len(rows) == 0 {
+		return nil
+	}
+
+	calls := make([]peripheral.Invocation, 0, 2*len(rows))
+	for _, y := range rows {
+		text, fg, bg := c.blitRow(y)
+		calls = append(calls,
+			peripheral.Invocation{Method: "setCursorPos", Args: []any{1, y}},
+			peripheral.Invocation{Method: "blit", Args: []any{text, fg, bg}},
+		)
+	}
+
+	_, err := c.m.h.CallBatch(ctx, calls...)
+	if err != nil {
+		return err
+	}
+
+	if c.sent == nil {
+		c.sent = make([][]Cell, c.height)
+	}
+	for _, y := range rows {
+		c.sent[y-1] = append(c.sent[y-1][:0], c.cells[y-1]...)
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package monitor
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/computer/colors"
+	"github.com/m4schini/computercraft-go/test"
+	"strings"
+	"testing"
+)
+
+func newTestCanvas(t *testing.T) (*test.ConnectionMock, *Canvas) {
+	conn := &test.ConnectionMock{Responses: [][]any{
+		{test.Peripheral("top", []string{"monitor"}, []string{"getSize", "setCursorPos", "blit"})},
+		{float64(5), float64(3)},
+	}}
+	m, err := Wrap(context.TODO(), conn, "top")
+	if err != nil {
+		t.Fatal(err)
+	}
+	c, err := NewCanvas(context.TODO(), m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Reset()
+	return conn, c
+}
+
+func TestCanvas_Flush(t *testing.T) {
+	//arrange
+	conn, c := newTestCanvas(t)
+
+	//act
+	err := c.Flush(context.TODO())
+
+	//assert
+	if err != nil || len(conn.Commands) != 1 {
+		t.FailNow()
+	}
+	t.Logf("command: %v", conn.Commands[0])
+	if strings.Count(conn.Commands[0], `"blit"`) != 3 {
+		t.FailNow()
+	}
+}
+
+func TestCanvas_Flush_onlyChangedRows(t *testing.T) {
+	//arrange
+	conn, c := newTestCanvas(t)
+	_ = c.Flush(context.TODO())
+	conn.Reset()
+
+	//act
+	c.Text = colors.Red
+	c.Write(2, 2, "hi")
+	err := c.Flush(context.TODO())
+
+	//assert
+	if err != nil || len(conn.Commands) != 1 {
+		t.FailNow()
+	}
+	t.Logf("command: %v", conn.Commands[0])
+	if strings.Count(conn.Commands[0], `"blit"`) != 1 || !strings.Contains(conn.Commands[0], `"blit", " hi  ", "0ee00", "fffff"`) {
+		t.FailNow()
+	}
+}
+
+func TestCanvas_Flush_unchanged(t *testing.T) {
+	//arrange
+	conn, c := newTestCanvas(t)
+	_ = c.Flush(context.TODO())
+	conn.Reset()
+
+	//act
+	err := c.Flush(context.TODO())
+
+	//assert
+	if err != nil || len(conn.Commands) != 0 {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,208 @@
+package monitor
+
+import (
+	"context"
+	"fmt"
+	"github.com/m4schini/computercraft-go/computer/colors"
+	"github.com/m4schini/computercraft-go/computer/peripheral"
+	"github.com/m4schini/computercraft-go/computer/term"
+	"github.com/m4schini/computercraft-go/connection"
+)
+
+const (
+	PeripheralType = "monitor"
+)
+
+// Monitor is a wrapped monitor peripheral.
+type Monitor struct {
+	h *peripheral.Handle
+}
+
+var _ term.Terminal = (*Monitor)(nil)
+
+// New wraps a peripheral handle as a monitor.
+func New(h *peripheral.Handle) (*Monitor, error) {
+	if !h.HasType(PeripheralType) {
+		return nil, fmt.Errorf("peripheral %v is not a %v", h.Name(), PeripheralType)
+	}
+	return &Monitor{h: h}, nil
+}
+
+// Wrap returns the monitor with the given name (or on the given side).
+func Wrap(ctx context.Context, conn connection.Connection, name string) (*Monitor, error) {
+	h, err := peripheral.Wrap(ctx, conn, name)
+	if err != nil {
+		return nil, err
+	}
+	return New(h)
+}
+
+// Find returns all attached monitors.
+func Find(ctx context.Context, conn connection.Connection) ([]*Monitor, error) {
+	handles, err := peripheral.Find(ctx, conn, PeripheralType)
+	if err != nil {
+		return nil, err
+	}
+
+	monitors := make([]*Monitor, len(handles))
+	for i, h := range handles {
+		monitors[i] = &Monitor{h: h}
+	}
+	return monitors, nil
+}
+
+// Name returns the peripheral name of the monitor
+func (m *Monitor) Name() string {
+	return m.h.Name()
+}
+
+func (m *Monitor) call(ctx context.Context, method string, args ...any) error {
+	_, err := m.h.Call(ctx, method, args...)
+	return err
+}
+
+// Write writes text at the current cursor position, moving the cursor to the end of the text.
+func (m *Monitor) Write(ctx context.Context, text string) error {
+	return m.call(ctx, "write", text)
+}
+
+// Blit writes text at the current cursor position. fg and bg contain a blit color
+// character for each character of text.
+func (m *Monitor) Blit(ctx context.Context, text, fg, bg string) error {
+	if len(text) != len(fg) || len(text) != len(bg) {
+		return fmt.Errorf("blit arguments must be the same length (%v, %v, %v)", len(text), len(fg), len(bg))
+	}
+	return m.call(ctx, "blit", text, fg, bg)
+}
+
+// Clear clears the monitor, filling it with the current background colour.
+func (m *Monitor) Clear(ctx context.Context) error {
+	return m.call(ctx, "clear")
+}
+
+// ClearLine clears the line the cursor is currently on.
+func (m *Monitor) ClearLine(ctx context.Context) error {
+	return m.call(ctx, "clearLine")
+}
+
+// Scroll moves all positions up (or down) by n lines.
+func (m *Monitor) Scroll(ctx context.Context, n int) error {
+	return m.call(ctx, "scroll", n)
+}
+
+// CursorPos gets the position of the cursor.
+func (m *Monitor) CursorPos(ctx context.Context) (x, y int, err error) {
+	res, err := m.h.Call(ctx, "getCursorPos")
+	if err != nil {
+		return 0, 0, err
+	}
+	err = connection.Unpack(res, &x, &y)
+	return x, y, err
+}
+
+// SetCursorPos sets the position of the cursor. The top left corner is 1, 1.
+func (m *Monitor) SetCursorPos(ctx context.Context, x, y int) error {
+	return m.call(ctx, "setCursorPos", x, y)
+}
+
+// CursorBlink checks if the cursor is currently blinking.
+func (m *Monitor) CursorBlink(ctx context.Context) (bool, error) {
+	res, err := m.h.Call(ctx, "getCursorBlink")
+	if err != nil {
+		return false, err
+	}
+	var blink bool
+	err = connection.Unpack(res, &blink)
+	return blink, err
+}
+
+// SetCursorBlink sets whether the cursor should be visible (and blinking).
+func (m *Monitor) SetCursorBlink(ctx context.Context, blink bool) error {
+	return m.call(ctx, "setCursorBlink", blink)
+}
+
+// Size gets the size of the monitor in characters.
+func (m *Monitor) Size(ctx context.Context) (width, height int, err error) {
+	res, err := m.h.Call(ctx, "getSize")
+	if err != nil {
+		return 0, 0, err
+	}
+	err = connection.Unpack(res, &width, &height)
+	return width, height, err
+}
+
+// IsColour checks if the monitor supports colours.
+func (m *Monitor) IsColour(ctx context.Context) (bool, error) {
+	res, err := m.h.Call(ctx, "isColour")
+	if err != nil {
+		return false, err
+	}
+	var colour bool
+	err = connection.Unpack(res, &colour)
+	return colour, err
+}
+
+// TextColour gets the colour new text is written in.
+func (m *Monitor) TextColour(ctx context.Context) (colors.Color, error) {
+	return m.colour(ctx, "getTextColour")
+}
+
+// SetTextColour sets the colour new text is written in.
+func (m *Monitor) SetTextColour(ctx context.Context, colour colors.Color) error {
+	return m.call(ctx, "setTextColour", colour)
+}
+
+// BackgroundColour gets the background colour new text is written with.
+func (m *Monitor) BackgroundColour(ctx context.Context) (colors.Color, error) {
+	return m.colour(ctx, "getBackgroundColour")
+}
+
+// SetBackgroundColour sets the background colour new text is written with.
+func (m *Monitor) SetBackgroundColour(ctx context.Context, colour colors.Color) error {
+	return m.call(ctx, "setBackgroundColour", colour)
+}
+
+func (m *Monitor) colour(ctx context.Context, method string) (colors.Color, error) {
+	res, err := m.h.Call(ctx, method)
+	if err != nil {
+		return 0, err
+	}
+	var colour colors.Color
+	err = connection.Unpack(res, &colour)
+	return colour, err
+}
+
+// PaletteColour gets the red, green and blue channels (0 to 1) a colour is displayed as.
+func (m *Monitor) PaletteColour(ctx context.Context, colour colors.Color) (r, g, b float64, err error) {
+	res, err := m.h.Call(ctx, "getPaletteColour", colour)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	err = connection.Unpack(res, &r, &g, &b)
+	return r, g, b, err
+}
+
+// SetPaletteColour changes the red, green and blue channels (0 to 1) a colour is displayed as.
+func (m *Monitor) SetPaletteColour(ctx context.Context, colour colors.Color, r, g, b float64) error {
+	return m.call(ctx, "setPaletteColour", colour, r, g, b)
+}
+
+// TextScale gets the text scale of the monitor.
+func (m *Monitor) TextScale(ctx context.Context) (float64, error) {
+	res, err := m.h.Call(ctx, "getTextScale")
+	if err != nil {
+		return 0, err
+	}
+	var scale float64
+	err = connection.Unpack(res, &scale)
+	return scale, err
+}
+
+// SetTextScale sets the text scale of the monitor. The scale must be a multiple of
+// 0.5 between 0.5 and 5. Changing the scale changes the size of the monitor.
+func (m *Monitor) SetTextScale(ctx context.Context, scale float64) error {
+	if scale < 0.5 || scale > 5 {
+		return fmt.Errorf("text scale %v out of range [0.5, 5]", scale)
+	}
+	return m.call(ctx, "setTextScale", scale)
+}
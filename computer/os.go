@@ -0,0 +1,257 @@
+package computer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/m4schini/computercraft-go/connection"
+	"math"
+	"time"
+)
+
+const (
+	OsModuleName = "os"
+
+	// TimerEvent is queued when a timer started with os.startTimer fires
+	TimerEvent = "timer"
+	// AlarmEvent is queued when an alarm set with os.setAlarm goes off
+	AlarmEvent = "alarm"
+)
+
+// Locale selects the clock used by os.epoch, os.day and os.time
+type Locale string
+
+const (
+	// LocaleInGame is the clock of the minecraft world
+	LocaleInGame Locale = "ingame"
+	// LocaleUTC is the real world time in UTC
+	LocaleUTC Locale = "utc"
+	// LocaleLocal is the real world time in the timezone of the server
+	LocaleLocal Locale = "local"
+)
+
+const (
+	// TicksPerDay is the number of game ticks of a minecraft day
+	TicksPerDay = 24000
+	// TicksPerHour is the number of game ticks of an in-game hour
+	TicksPerHour = TicksPerDay / 24
+	// TickDuration is the real duration of a game tick
+	TickDuration = 50 * time.Millisecond
+)
+
+// GameTime is a time of the in-game day in hours, like returned by os.time (e.g. 13.5 is 13:30).
+type GameTime float64
+
+// GameTimeFromTicks converts a minecraft day time in ticks (0 is 6:00) into a GameTime
+func GameTimeFromTicks(ticks int) GameTime {
+	ticks = ((ticks+6*TicksPerHour)%TicksPerDay + TicksPerDay) % TicksPerDay
+	return GameTime(float64(ticks) / TicksPerHour)
+}
+
+// Ticks returns the minecraft day time in ticks (0 is 6:00)
+func (g GameTime) Ticks() int {
+	ticks := int(math.Round(float64(g)*TicksPerHour)) - 6*TicksPerHour
+	return (ticks%TicksPerDay + TicksPerDay) % TicksPerDay
+}
+
+// Hour returns the hour of the day (0-23)
+func (g GameTime) Hour() int {
+	return int(g) % 24
+}
+
+// Minute returns the minute of the hour (0-59)
+func (g GameTime) Minute() int {
+	return int((float64(g) - math.Floor(float64(g))) * 60)
+}
+
+// SinceMidnight returns the in-game time passed since midnight
+func (g GameTime) SinceMidnight() time.Duration {
+	return time.Duration(float64(g) * float64(time.Hour))
+}
+
+// Until returns the real time until the in-game clock reaches t. If t was already
+// reached today, the duration until t on the next day is returned.
+func (g GameTime) Until(t GameTime) time.Duration {
+	ticks := ((t.Ticks()-g.Ticks())%TicksPerDay + TicksPerDay) % TicksPerDay
+	return time.Duration(ticks) * TickDuration
+}
+
+func (g GameTime) String() string {
+	return fmt.Sprintf("%02d:%02d", g.Hour(), g.Minute())
+}
+
+func osCall(ctx context.Context, conn connection.Connection, function string, args ...any) ([]any, error) {
+	arguments, err := connection.LuaArgs(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := conn.Execute(ctx, fmt.Sprintf("%v.%v(%v)", OsModuleName, function, arguments))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+	return res, nil
+}
+
+// Epoch returns the current time of a clock. The in-game clock starts at the creation of the world.
+func Epoch(ctx context.Context, conn connection.Connection, locale Locale) (time.Time, error) {
+	res, err := osCall(ctx, conn, "epoch", string(locale))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var millis int64
+	err = connection.Unpack(res, &millis)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if locale == LocaleUTC {
+		return time.UnixMilli(millis).UTC(), nil
+	}
+	return time.UnixMilli(millis), nil
+}
+
+// Day returns the current day of a clock. The in-game clock counts days since the creation of the world,
+// real clocks count days since 1970-01-01.
+func Day(ctx context.Context, conn connection.Connection, locale Locale) (day int, err error) {
+	res, err := osCall(ctx, conn, "day", string(locale))
+	if err != nil {
+		return 0, err
+	}
+	err = connection.Unpack(res, &day)
+	return day, err
+}
+
+// QueueEvent adds an event to the event queue of the computer
+func QueueEvent(ctx context.Context, conn connection.Connection, name string, args ...any) error {
+	_, err := osCall(ctx, conn, "queueEvent", append([]any{name}, args...)...)
+	return err
+}
+
+// PullEvent waits for the next event with the given name
+func PullEvent(ctx context.Context, conn connection.Connection, name string) (connection.Event, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := conn.Subscribe(ctx, name)
+	if err != nil {
+		return connection.Event{}, err
+	}
+
+	event, ok := <-events
+	if !ok {
+		if ctx.Err() != nil {
+			return connection.Event{}, ctx.Err()
+		}
+		return connection.Event{}, connection.ClosedChannelErr
+	}
+	return event, nil
+}
+
+// Timer is a timer of a computer. When it fires, the time is sent on C.
+type Timer struct {
+	ID int
+	C  <-chan time.Time
+
+	conn   connection.Connection
+	cancel context.CancelFunc
+}
+
+// Alarm is an alarm of a computer. When it goes off, the time is sent on C.
+type Alarm struct {
+	ID int
+	C  <-chan time.Time
+
+	conn   connection.Connection
+	cancel context.CancelFunc
+}
+
+// await subscribes to an event and starts something that is identified by an id in the first argument of the event.
+// The returned channel receives the time, when the event with the id was received.
+func await(ctx context.Context, conn connection.Connection, event string, start func() (int, error)) (int, <-chan time.Time, context.CancelFunc, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	events, err := conn.Subscribe(ctx, event)
+	if err != nil {
+		cancel()
+		return 0, nil, nil, err
+	}
+
+	id, err := start()
+	if err != nil {
+		cancel()
+		return 0, nil, nil, err
+	}
+
+	c := make(chan time.Time, 1)
+	go func() {
+		defer cancel()
+		for e := range events {
+			var eventId int
+			if e.Arg(0, &eventId) == nil && eventId == id {
+				c <- time.Now()
+				return
+			}
+		}
+	}()
+	return id, c, cancel, nil
+}
+
+// StartTimer starts a timer, that fires after d. The timer is delivered through the event
+// stream, it is stopped when ctx is done.
+func StartTimer(ctx context.Context, conn connection.Connection, d time.Duration) (*Timer, error) {
+	t := &Timer{conn: conn}
+	var err error
+	var c <-chan time.Time
+	t.ID, c, t.cancel, err = await(ctx, conn, TimerEvent, func() (id int, err error) {
+		res, err := osCall(ctx, conn, "startTimer", d.Seconds())
+		if err != nil {
+			return 0, err
+		}
+		err = connection.Unpack(res, &id)
+		return id, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	t.C = c
+	return t, nil
+}
+
+// Stop cancels the timer. C does not receive anything afterwards.
+func (t *Timer) Stop(ctx context.Context) error {
+	t.cancel()
+	_, err := osCall(ctx, t.conn, "cancelTimer", t.ID)
+	return err
+}
+
+// SetAlarm sets an alarm, that goes off at the given in-game time. The alarm is delivered
+// through the event stream, it is canceled when ctx is done.
+func SetAlarm(ctx context.Context, conn connection.Connection, at GameTime) (*Alarm, error) {
+	if at < 0 || at >= 24 {
+		return nil, errors.New("alarm time must be between 0 and 24")
+	}
+
+	a := &Alarm{conn: conn}
+	var err error
+	var c <-chan time.Time
+	a.ID, c, a.cancel, err = await(ctx, conn, AlarmEvent, func() (id int, err error) {
+		res, err := osCall(ctx, conn, "setAlarm", float64(at))
+		if err != nil {
+			return 0, err
+		}
+		err = connection.Unpack(res, &id)
+		return id, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	a.C = c
+	return a, nil
+}
+
+// Cancel cancels the alarm. C does not receive anything afterwards.
+func (a *Alarm) Cancel(ctx context.Context) error {
+	a.cancel()
+	_, err := osCall(ctx, a.conn, "cancelAlarm", a.ID)
+	return err
+}
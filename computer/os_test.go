@@ -0,0 +1,72 @@
+package computer
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/test"
+	"testing"
+	"time"
+)
+
+func TestGameTime(t *testing.T) {
+	//arrange
+	var morning = GameTime(6)
+	var evening = GameTimeFromTicks(12000)
+
+	//act
+	until := morning.Until(evening)
+	back := evening.Until(morning)
+
+	//assert
+	t.Logf("actual: %v %v %v %v", morning, evening, until, back)
+	if evening != 18 || evening.String() != "18:00" || until != 10*time.Minute || back != 10*time.Minute {
+		t.FailNow()
+	}
+	if GameTime(13.5).String() != "13:30" || GameTime(0).Ticks() != 18000 {
+		t.FailNow()
+	}
+}
+
+func TestStartTimer(t *testing.T) {
+	//arrange
+	var expected = `os.startTimer(1.5)`
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{float64(3)})
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	//act
+	timer, err := StartTimer(ctx, conn, 1500*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Emit(TimerEvent, float64(2))
+	conn.Emit(TimerEvent, float64(3))
+
+	//assert
+	t.Logf("expected: %v", expected)
+	t.Logf("  actual: %v", conn.Executed()[0])
+	if conn.Executed()[0] != expected || timer.ID != 3 {
+		t.FailNow()
+	}
+	select {
+	case <-timer.C:
+	case <-time.After(time.Second):
+		t.Log("timer did not fire")
+		t.FailNow()
+	}
+}
+
+func TestUptime(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{12.35})
+
+	//act
+	uptime, err := Uptime(context.TODO(), conn)
+
+	//assert
+	t.Logf("actual: %v", uptime)
+	if err != nil || uptime != 12350*time.Millisecond {
+		t.FailNow()
+	}
+}
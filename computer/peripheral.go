@@ -2,13 +2,12 @@ package computer
 
 import (
 	"context"
-	"fmt"
+	"github.com/m4schini/computercraft-go/computer/peripheral"
 	"github.com/m4schini/computercraft-go/connection"
-	"strings"
 )
 
 const (
-	PeripheralModuleName = "peripheral"
+	PeripheralModuleName = peripheral.ModuleName
 )
 
 type PeripheralType string
@@ -25,80 +24,27 @@ type Peripheral interface {
 }
 
 func Names(ctx context.Context, conn connection.Connection) ([]string, error) {
-	response, err := conn.Execute(ctx, PeripheralModuleName+".getNames()")
-	if err != nil || len(response) != 1 {
-		return []string{}, err
-	}
-
-	names, ok := response[0].([]string)
-	if !ok {
-		return []string{}, nil
-	}
-
-	return names, nil
+	return peripheral.Names(ctx, conn)
 }
 
 func IsPresent(ctx context.Context, conn connection.Connection, name string) (bool, error) {
-	return connection.DoActionBool(ctx, conn, fmt.Sprintf("%v.isPresent(\"%v\")", PeripheralModuleName, name))
+	return peripheral.IsPresent(ctx, conn, name)
 }
 
 func GetType(ctx context.Context, conn connection.Connection, name string) ([]string, error) {
-	response, err := conn.Execute(ctx, fmt.Sprintf("%v.getType(\"%v\")", PeripheralModuleName, name))
-	if err != nil || len(response) != 1 {
-		return []string{}, err
-	}
-
-	names, ok := response[0].([]string)
-	if !ok {
-		return []string{}, nil
-	}
-
-	return names, nil
+	return peripheral.Types(ctx, conn, name)
 }
 
 func HasType(ctx context.Context, conn connection.Connection, name string, peripheralType PeripheralType) (bool, error) {
-	response, err := conn.Execute(ctx, fmt.Sprintf("%v.hasType(\"%v\", \"%v\")", PeripheralModuleName, name, peripheralType))
-	if err != nil || len(response) != 1 {
-		return false, err
-	}
-
-	hasType, ok := response[0].(bool)
-	if !ok {
-		return false, nil
-	}
-
-	return hasType, nil
+	return peripheral.HasType(ctx, conn, name, string(peripheralType))
 }
 
 func GetMethods(ctx context.Context, conn connection.Connection, name string) ([]string, error) {
-	response, err := conn.Execute(ctx, fmt.Sprintf("%v.getMethods(\"%v\")", PeripheralModuleName, name))
-	if err != nil || len(response) != 1 {
-		return []string{}, err
-	}
-
-	methods, ok := response[0].([]string)
-	if !ok {
-		return []string{}, nil
-	}
-
-	return methods, nil
+	return peripheral.Methods(ctx, conn, name)
 }
 
+// Call calls a method of a peripheral. See peripheral.Wrap for a handle that caches
+// the methods of a peripheral.
 func Call(ctx context.Context, conn connection.Connection, name, method string, args ...any) ([]any, error) {
-	arguments := make([]string, len(args))
-
-	for i, arg := range args {
-		arguments[i] = fmt.Sprintf("%v", arg)
-	}
-
-	response, err := conn.Execute(ctx, fmt.Sprintf("%v.call(\"%v\", \"%v\", \"%v\")",
-		PeripheralModuleName,
-		name,
-		method,
-		strings.Join(arguments, "\", \"")))
-	if err != nil || len(response) != 1 {
-		return []any{}, err
-	}
-
-	return response, nil
+	return peripheral.Call(ctx, conn, name, method, args...)
 }
@@ -0,0 +1,288 @@
+package peripheral
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/m4schini/computercraft-go/connection"
+	"strings"
+)
+
+const (
+	ModuleName = "peripheral"
+)
+
+var (
+	ErrNotPresent    = errors.New("peripheral not present")
+	ErrUnknownMethod = errors.New("peripheral has no such method")
+)
+
+// describeFunc is a lua function returning the name, types and methods of a peripheral
+const describeFunc = `function(name) return {name = name, types = {peripheral.getType(name)}, methods = peripheral.getMethods(name)} end`
+
+type description struct {
+	Name    string   `lua:"name"`
+	Types   []string `lua:"types"`
+	Methods []string `lua:"methods"`
+}
+
+// Handle is a wrapped peripheral. Its types and methods are fetched once when it
+// is wrapped, so method calls do not need an additional round trip.
+type Handle struct {
+	conn    connection.Connection
+	name    string
+	types   []string
+	methods map[string]struct{}
+}
+
+func newHandle(conn connection.Connection, d description) *Handle {
+	h := &Handle{
+		conn:    conn,
+		name:    d.Name,
+		types:   d.Types,
+		methods: make(map[string]struct{}, len(d.Methods)),
+	}
+	for _, method := range d.Methods {
+		h.methods[method] = struct{}{}
+	}
+	return h
+}
+
+// Wrap returns a handle for the peripheral with the given name (or side).
+func Wrap(ctx context.Context, conn connection.Connection, name string) (*Handle, error) {
+	res, err := conn.Execute(ctx, fmt.Sprintf("peripheral.isPresent(%[1]v) and (%[2]v)(%[1]v) or nil",
+		connection.LuaString(name),
+		describeFunc))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+
+	if len(res) < 1 || res[0] == nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotPresent, name)
+	}
+
+	var d description
+	err = connection.Decode(res[0], &d)
+	if err != nil {
+		return nil, err
+	}
+
+	return newHandle(conn, d), nil
+}
+
+// Find returns handles for all peripherals with the given type, like peripheral.find.
+func Find(ctx context.Context, conn connection.Connection, peripheralType string) ([]*Handle, error) {
+	res, err := conn.Execute(ctx, fmt.Sprintf(
+		"(function(t, describe) local r = {} for _, name in ipairs(peripheral.getNames()) do if peripheral.hasType(name, t) then r[#r + 1] = describe(name) end end return r end)(%v, %v)",
+		connection.LuaString(peripheralType),
+		describeFunc))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+
+	if len(res) < 1 {
+		return nil, connection.RpcError(errors.New("unexpected data length"))
+	}
+
+	var descriptions []description
+	err = connection.Decode(res[0], &descriptions)
+	if err != nil {
+		return nil, err
+	}
+
+	handles := make([]*Handle, len(descriptions))
+	for i, d := range descriptions {
+		handles[i] = newHandle(conn, d)
+	}
+	return handles, nil
+}
+
+// Names returns the names of all attached peripherals.
+func Names(ctx context.Context, conn connection.Connection) ([]string, error) {
+	res, err := conn.Execute(ctx, ModuleName+".getNames()")
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+
+	if len(res) < 1 {
+		return nil, connection.RpcError(errors.New("unexpected data length"))
+	}
+
+	var names []string
+	err = connection.Decode(res[0], &names)
+	return names, err
+}
+
+// IsPresent checks if a peripheral is attached with the given name (or on the given side).
+func IsPresent(ctx context.Context, conn connection.Connection, name string) (bool, error) {
+	return connection.DoActionBool(ctx, conn, fmt.Sprintf("%v.isPresent(%v)", ModuleName, connection.LuaString(name)))
+}
+
+// Types returns all types of the peripheral with the given name.
+func Types(ctx context.Context, conn connection.Connection, name string) ([]string, error) {
+	res, err := conn.Execute(ctx, fmt.Sprintf("%v.getType(%v)", ModuleName, connection.LuaString(name)))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+
+	types := make([]string, 0, len(res))
+	for _, t := range res {
+		if s, ok := t.(string); ok {
+			types = append(types, s)
+		}
+	}
+	if len(types) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNotPresent, name)
+	}
+	return types, nil
+}
+
+// HasType checks if the peripheral with the given name is of a certain type.
+func HasType(ctx context.Context, conn connection.Connection, name, peripheralType string) (bool, error) {
+	res, err := conn.Execute(ctx, fmt.Sprintf("%v.hasType(%v, %v)", ModuleName, connection.LuaString(name), connection.LuaString(peripheralType)))
+	if err != nil {
+		return false, connection.RpcError(err)
+	}
+
+	if len(res) < 1 || res[0] == nil {
+		return false, fmt.Errorf("%w: %v", ErrNotPresent, name)
+	}
+
+	hasType, ok := res[0].(bool)
+	if !ok {
+		return false, connection.UnexpectedDatatypeErr
+	}
+	return hasType, nil
+}
+
+// Methods returns the names of all methods of the peripheral with the given name.
+func Methods(ctx context.Context, conn connection.Connection, name string) ([]string, error) {
+	res, err := conn.Execute(ctx, fmt.Sprintf("%v.getMethods(%v)", ModuleName, connection.LuaString(name)))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+
+	if len(res) < 1 || res[0] == nil {
+		return nil, fmt.Errorf("%w: %v", ErrNotPresent, name)
+	}
+
+	var methods []string
+	err = connection.Decode(res[0], &methods)
+	return methods, err
+}
+
+// Call calls a method of the peripheral with the given name. The arguments are passed
+// with their lua types, and all return values of the method are returned.
+func Call(ctx context.Context, conn connection.Connection, name, method string, args ...any) ([]any, error) {
+	command, err := CallExpr(name, method, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := conn.Execute(ctx, command)
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+	return res, nil
+}
+
+// CallExpr returns the lua expression that calls a method of the peripheral with the given name.
+func CallExpr(name, method string, args ...any) (string, error) {
+	arguments, err := connection.LuaArgs(append([]any{name, method}, args...)...)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%v.call(%v)", ModuleName, arguments), nil
+}
+
+// Name returns the name of the peripheral
+func (h *Handle) Name() string {
+	return h.name
+}
+
+// Types returns all types of the peripheral
+func (h *Handle) Types() []string {
+	types := make([]string, len(h.types))
+	copy(types, h.types)
+	return types
+}
+
+// HasType checks if the peripheral is of a certain type
+func (h *Handle) HasType(peripheralType string) bool {
+	for _, t := range h.types {
+		if t == peripheralType {
+			return true
+		}
+	}
+	return false
+}
+
+// Methods returns the names of all methods of the peripheral
+func (h *Handle) Methods() []string {
+	methods := make([]string, 0, len(h.methods))
+	for method := range h.methods {
+		methods = append(methods, method)
+	}
+	return methods
+}
+
+// HasMethod checks if the peripheral has a method with the given name
+func (h *Handle) HasMethod(method string) bool {
+	_, ok := h.methods[method]
+	return ok
+}
+
+// Call calls a method of the peripheral and returns all of its return values.
+func (h *Handle) Call(ctx context.Context, method string, args ...any) ([]any, error) {
+	if !h.HasMethod(method) {
+		return nil, fmt.Errorf("%w: %v.%v", ErrUnknownMethod, h.name, method)
+	}
+	return Call(ctx, h.conn, h.name, method, args...)
+}
+
+// Invocation is a single method call of a batch.
+type Invocation struct {
+	Method string
+	Args   []any
+}
+
+// CallBatch calls multiple methods of the peripheral in a single round trip and
+// returns the return values of every method.
+func (h *Handle) CallBatch(ctx context.Context, calls ...Invocation) ([][]any, error) {
+	if len(calls) == 0 {
+		return nil, nil
+	}
+
+	expressions := make([]string, len(calls))
+	for i, call := range calls {
+		if !h.HasMethod(call.Method) {
+			return nil, fmt.Errorf("%w: %v.%v", ErrUnknownMethod, h.name, call.Method)
+		}
+		expression, err := CallExpr(h.name, call.Method, call.Args...)
+		if err != nil {
+			return nil, err
+		}
+		expressions[i] = "{" + expression + "}"
+	}
+
+	res, err := h.conn.Execute(ctx, fmt.Sprintf("{%v}", strings.Join(expressions, ", ")))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+
+	var results [][]any
+	err = connection.Unpack(res, &results)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) < len(calls) {
+		// results can always be indexed like the calls
+		results = append(results, make([][]any, len(calls)-len(results))...)
+	}
+	return results, nil
+}
+
+// Conn returns the connection of the computer the peripheral is attached to
+func (h *Handle) Conn() connection.Connection {
+	return h.conn
+}
@@ -0,0 +1,107 @@
+package peripheral
+
+import (
+	"context"
+	"errors"
+	"github.com/m4schini/computercraft-go/connection"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func NewTestConnection() (out <-chan []byte, in chan<- []byte, conn connection.Connection) {
+	outCh := make(chan []byte, 2)
+	inCh := make(chan []byte, 2)
+
+	conn = connection.New(inCh, outCh)
+	out = outCh
+	in = inCh
+
+	return out, in, conn
+}
+
+func TestWrap(t *testing.T) {
+	//arrange
+	var wg sync.WaitGroup
+	var h *Handle
+	var err error
+	out, in, conn := NewTestConnection()
+
+	//act
+	wg.Add(1)
+	go func() {
+		h, err = Wrap(context.TODO(), conn, "left")
+		wg.Done()
+	}()
+
+	command := <-out
+	t.Logf("command: %v", string(command))
+	in <- []byte(`[{"name": "left", "types": ["monitor"], "methods": ["write", "setCursorPos"]}]`)
+	wg.Wait()
+
+	//assert
+	if err != nil || h.Name() != "left" || !h.HasType("monitor") || !h.HasMethod("write") {
+		t.FailNow()
+	}
+}
+
+func TestWrap_notPresent(t *testing.T) {
+	//arrange
+	var wg sync.WaitGroup
+	var err error
+	out, in, conn := NewTestConnection()
+
+	//act
+	wg.Add(1)
+	go func() {
+		_, err = Wrap(context.TODO(), conn, "left")
+		wg.Done()
+	}()
+
+	<-out
+	in <- []byte(`[]`)
+	wg.Wait()
+
+	//assert
+	t.Logf("Error: %v", err)
+	if !errors.Is(err, ErrNotPresent) {
+		t.FailNow()
+	}
+}
+
+func TestHandle_Call(t *testing.T) {
+	//arrange
+	var wg sync.WaitGroup
+	var actual []any
+	var err error
+	out, in, conn := NewTestConnection()
+	h := newHandle(conn, description{Name: "left", Types: []string{"monitor"}, Methods: []string{"setCursorPos"}})
+
+	//act
+	wg.Add(1)
+	go func() {
+		actual, err = h.Call(context.TODO(), "setCursorPos", 5, 2)
+		wg.Done()
+	}()
+
+	command := string(<-out)
+	t.Logf("command: %v", command)
+	in <- []byte(`[true, null, "x"]`)
+	wg.Wait()
+
+	//assert
+	if !strings.Contains(command, `peripheral.call(\"left\", \"setCursorPos\", 5, 2)`) {
+		t.FailNow()
+	}
+	if err != nil || len(actual) != 3 || actual[0] != true || actual[1] != nil {
+		t.FailNow()
+	}
+}
+
+func TestHandle_Call_unknownMethod(t *testing.T) {
+	h := newHandle(connection.NewNopConnection(), description{Name: "left"})
+	_, err := h.Call(context.TODO(), "explode")
+	if !errors.Is(err, ErrUnknownMethod) {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,77 @@
+package computer
+
+import (
+	"context"
+	"fmt"
+	"github.com/m4schini/computercraft-go/computer/gps"
+	"github.com/m4schini/computercraft-go/connection"
+	"time"
+)
+
+const PocketModuleName = "pocket"
+
+// upgradeFunc returns the type of the upgrade on the back, and whether it is a wireless modem
+const upgradeFunc = `function() local t = peripheral.getType("back") return t, t == "modem" and peripheral.call("back", "isWireless") end`
+
+func EquipBack(ctx context.Context, conn connection.Connection) (bool, error) {
+	return connection.DoAction(ctx, conn, PocketModuleName+".equipBack()", ParseReason)
+}
+
+func UnequipBack(ctx context.Context, conn connection.Connection) (bool, error) {
+	return connection.DoAction(ctx, conn, PocketModuleName+".unequipBack()", ParseReason)
+}
+
+func upgrade(ctx context.Context, conn connection.Connection) (upgrade PocketUpgrade, wireless bool, err error) {
+	res, err := conn.Execute(ctx, fmt.Sprintf("(%v)()", upgradeFunc))
+	if err != nil {
+		return PocketUpgradeNone, false, connection.RpcError(err)
+	}
+	err = connection.Unpack(res, &upgrade, &wireless)
+	return upgrade, wireless, err
+}
+
+// Upgrade returns the upgrade equipped on the back of a pocket computer
+func Upgrade(ctx context.Context, conn connection.Connection) (PocketUpgrade, error) {
+	u, _, err := upgrade(ctx, conn)
+	return u, err
+}
+
+// IsWireless checks if a wireless or ender modem is equipped on the back of a pocket computer
+func IsWireless(ctx context.Context, conn connection.Connection) (bool, error) {
+	_, wireless, err := upgrade(ctx, conn)
+	return wireless, err
+}
+
+type pocket struct {
+	*computer
+	gps.ModemGPS
+}
+
+var _ Pocket = (*pocket)(nil)
+
+func NewPocket(conn connection.Connection) *pocket {
+	p := new(pocket)
+	p.computer = NewComputer(conn)
+	p.ModemGPS = gps.ModemGPS{Conn: conn}
+	return p
+}
+
+func (p *pocket) EquipBack(ctx context.Context) (bool, error) {
+	return EquipBack(ctx, p.conn)
+}
+
+func (p *pocket) UnequipBack(ctx context.Context) (bool, error) {
+	return UnequipBack(ctx, p.conn)
+}
+
+func (p *pocket) Upgrade(ctx context.Context) (PocketUpgrade, error) {
+	return Upgrade(ctx, p.conn)
+}
+
+func (p *pocket) IsWireless(ctx context.Context) (bool, error) {
+	return IsWireless(ctx, p.conn)
+}
+
+func (p *pocket) Track(ctx context.Context, interval time.Duration) <-chan gps.Position {
+	return gps.Track(ctx, p, interval)
+}
@@ -0,0 +1,54 @@
+package computer
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/test"
+	"testing"
+)
+
+func TestPocket_Upgrade(t *testing.T) {
+	tests := []struct {
+		name             string
+		response         []any
+		expected         PocketUpgrade
+		expectedWireless bool
+	}{
+		{name: "none", response: []any{}, expected: PocketUpgradeNone},
+		{name: "speaker", response: []any{"speaker", false}, expected: PocketUpgradeSpeaker},
+		{name: "wireless modem", response: []any{"modem", true}, expected: PocketUpgradeModem, expectedWireless: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//arrange
+			conn := &test.ConnectionMock{}
+			conn.Respond(tt.response, tt.response)
+			p := NewPocket(conn)
+
+			//act
+			actual, err := p.Upgrade(context.TODO())
+			wireless, wirelessErr := p.IsWireless(context.TODO())
+
+			//assert
+			t.Logf("actual: %q %v", actual, wireless)
+			if err != nil || wirelessErr != nil || actual != tt.expected || wireless != tt.expectedWireless {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestPocket_EquipBack(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{false, "Nothing to equip"})
+	p := NewPocket(conn)
+
+	//act
+	equipped, err := p.EquipBack(context.TODO())
+
+	//assert
+	t.Logf("actual: %v %v", equipped, err)
+	if equipped || err == nil || conn.Executed()[0] != "pocket.equipBack()" {
+		t.FailNow()
+	}
+}
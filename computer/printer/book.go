@@ -0,0 +1,226 @@
+package printer
+
+import (
+	"context"
+	"fmt"
+	"github.com/m4schini/computercraft-go/connection"
+	"regexp"
+	"strings"
+)
+
+// printPageFunc writes the lines of a started page, and finishes it
+const printPageFunc = `function(name, title, lines) local p = peripheral.wrap(name) p.setPageTitle(title) for y, line in ipairs(lines) do p.setCursorPos(1, y) p.write(line) end return p.endPage() end`
+
+// WordWrap breaks text into lines of at most width characters. Lines are broken between words,
+// words longer than a line are split. Line breaks of the text and the indentation of its lines are kept.
+func WordWrap(text string, width int) []string {
+	if width < 1 {
+		width = 1
+	}
+
+	var lines []string
+	for _, paragraph := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		words := strings.Fields(paragraph)
+		if len(words) == 0 {
+			lines = append(lines, "")
+			continue
+		}
+
+		line := paragraph[:len(paragraph)-len(strings.TrimLeft(paragraph, " \t"))]
+		line = strings.ReplaceAll(line, "\t", "    ")
+		empty := true
+		for _, word := range words {
+			if empty && len(line)+len(word) <= width {
+				line += word
+				empty = false
+				continue
+			}
+			if !empty && len(line)+1+len(word) <= width {
+				line += " " + word
+				continue
+			}
+
+			// the word does not fit into the current line
+			if !empty {
+				lines = append(lines, line)
+			}
+			for len(word) > width {
+				lines = append(lines, word[:width])
+				word = word[width:]
+			}
+			line, empty = word, false
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// Paginate splits lines into pages of at most height lines. Empty lines at the top of
+// a page are dropped. There is always at least one page.
+func Paginate(lines []string, height int) [][]string {
+	if height < 1 {
+		height = 1
+	}
+
+	var pages [][]string
+	var page []string
+	for _, line := range lines {
+		if len(page) == 0 && line == "" {
+			continue
+		}
+		page = append(page, line)
+		if len(page) == height {
+			pages = append(pages, page)
+			page = nil
+		}
+	}
+	if len(page) > 0 || len(pages) == 0 {
+		pages = append(pages, page)
+	}
+	return pages
+}
+
+var (
+	headingPattern = regexp.MustCompile(`^#{1,6}\s+`)
+	listPattern    = regexp.MustCompile(`^(\s*)[-*+]\s+`)
+	imagePattern   = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	linkPattern    = regexp.MustCompile(`\[([^\]]*)\]\([^)]*\)`)
+	emphasis       = strings.NewReplacer("**", "", "__", "", "`", "", "*", "")
+)
+
+// FromMarkdown converts a markdown document into plain text for printing. Headings, emphasis
+// and links are reduced to their text, list items start with "- ", and the lines of a
+// paragraph are joined, so they can be wrapped to the page. Code blocks are kept as they are.
+func FromMarkdown(markdown string) string {
+	var out []string
+	paragraph := false
+	code := false
+	for _, line := range strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			code = !code
+			paragraph = false
+			continue
+		}
+
+		switch {
+		case code:
+			out = append(out, line)
+		case trimmed == "":
+			out = append(out, "")
+			paragraph = false
+		case headingPattern.MatchString(trimmed):
+			out = append(out, inline(headingPattern.ReplaceAllString(trimmed, "")))
+			paragraph = false
+		case listPattern.MatchString(line):
+			out = append(out, inline(listPattern.ReplaceAllString(line, "$1- ")))
+			paragraph = true
+		case paragraph:
+			out[len(out)-1] += " " + inline(trimmed)
+		default:
+			out = append(out, inline(trimmed))
+			paragraph = true
+		}
+
+		// two trailing spaces are a hard line break
+		if strings.HasSuffix(line, "  ") {
+			paragraph = false
+		}
+	}
+	return strings.Join(out, "\n")
+}
+
+func inline(text string) string {
+	text = imagePattern.ReplaceAllString(text, "$1")
+	text = linkPattern.ReplaceAllString(text, "$1")
+	return emphasis.Replace(text)
+}
+
+// checkSupplies returns ErrOutOfInk or ErrOutOfPaper if the printer can not print another page
+func (p *Printer) checkSupplies(ctx context.Context) error {
+	ink, err := p.InkLevel(ctx)
+	if err != nil {
+		return err
+	}
+	if ink < 1 {
+		return fmt.Errorf("%w: %v", ErrOutOfInk, p.Name())
+	}
+
+	paper, err := p.PaperLevel(ctx)
+	if err != nil {
+		return err
+	}
+	if paper < 1 {
+		return fmt.Errorf("%w: %v", ErrOutOfPaper, p.Name())
+	}
+	return nil
+}
+
+// PrintDocument word-wraps text to the page size and prints it on as many pages as needed.
+// Pages of multi-page documents are titled "title (n/m)". Ink and paper are checked before
+// every page, if they run out ErrOutOfInk or ErrOutOfPaper is returned together with the
+// number of pages, that were already printed. Markdown can be printed with FromMarkdown.
+func (p *Printer) PrintDocument(ctx context.Context, title, text string) (printed int, err error) {
+	err = p.checkSupplies(ctx)
+	if err != nil {
+		return 0, err
+	}
+	err = p.NewPage(ctx)
+	if err != nil {
+		return 0, err
+	}
+	width, height, err := p.PageSize(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	pages := Paginate(WordWrap(text, width), height)
+	for i, lines := range pages {
+		if i > 0 {
+			err = p.checkSupplies(ctx)
+			if err == nil {
+				err = p.NewPage(ctx)
+			}
+			if err != nil {
+				return printed, fmt.Errorf("page %v of %v: %w", i+1, len(pages), err)
+			}
+		}
+
+		pageTitle := title
+		if len(pages) > 1 {
+			pageTitle = fmt.Sprintf("%v (%v/%v)", title, i+1, len(pages))
+		}
+		err = p.printPage(ctx, pageTitle, lines)
+		if err != nil {
+			return printed, fmt.Errorf("page %v of %v: %w", i+1, len(pages), err)
+		}
+		printed++
+	}
+	return printed, nil
+}
+
+// printPage writes the lines to the started page and finishes it in a single round trip
+func (p *Printer) printPage(ctx context.Context, title string, lines []string) error {
+	if lines == nil {
+		lines = []string{}
+	}
+	arguments, err := connection.LuaArgs(p.Name(), title, lines)
+	if err != nil {
+		return err
+	}
+
+	res, err := p.h.Conn().Execute(ctx, fmt.Sprintf("(%v)(%v)", printPageFunc, arguments))
+	if err != nil {
+		return connection.RpcError(err)
+	}
+
+	var ended bool
+	err = connection.Unpack(res, &ended)
+	if err != nil {
+		return err
+	}
+	if !ended {
+		return fmt.Errorf("%w: %v", ErrOutputTrayFull, p.Name())
+	}
+	return nil
+}
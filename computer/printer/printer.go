@@ -0,0 +1,162 @@
+package printer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/m4schini/computercraft-go/computer/peripheral"
+	"github.com/m4schini/computercraft-go/connection"
+)
+
+const (
+	PeripheralType = "printer"
+
+	// PageWidth is the width of a printed page in characters
+	PageWidth = 25
+	// PageHeight is the height of a printed page in lines
+	PageHeight = 21
+)
+
+var (
+	ErrOutOfInk   = errors.New("printer is out of ink")
+	ErrOutOfPaper = errors.New("printer is out of paper")
+	// ErrPageNotStarted is returned by NewPage, if the printer has no paper or ink
+	ErrPageNotStarted = errors.New("page could not be started")
+	// ErrOutputTrayFull is returned by EndPage, if the page can not be moved into the output tray
+	ErrOutputTrayFull = errors.New("output tray of printer is full")
+)
+
+// Printer is a wrapped printer.
+type Printer struct {
+	h *peripheral.Handle
+}
+
+// New wraps a peripheral handle as a printer.
+func New(h *peripheral.Handle) (*Printer, error) {
+	if !h.HasType(PeripheralType) {
+		return nil, fmt.Errorf("peripheral %v is not a %v", h.Name(), PeripheralType)
+	}
+	return &Printer{h: h}, nil
+}
+
+// Wrap returns the printer with the given name (or on the given side).
+func Wrap(ctx context.Context, conn connection.Connection, name string) (*Printer, error) {
+	h, err := peripheral.Wrap(ctx, conn, name)
+	if err != nil {
+		return nil, err
+	}
+	return New(h)
+}
+
+// Find returns all attached printers.
+func Find(ctx context.Context, conn connection.Connection) ([]*Printer, error) {
+	handles, err := peripheral.Find(ctx, conn, PeripheralType)
+	if err != nil {
+		return nil, err
+	}
+
+	printers := make([]*Printer, len(handles))
+	for i, h := range handles {
+		printers[i] = &Printer{h: h}
+	}
+	return printers, nil
+}
+
+// Name returns the peripheral name of the printer
+func (p *Printer) Name() string {
+	return p.h.Name()
+}
+
+// NewPage starts a new page. It uses one sheet of paper and one unit of ink.
+func (p *Printer) NewPage(ctx context.Context) error {
+	res, err := p.h.Call(ctx, "newPage")
+	if err != nil {
+		return err
+	}
+
+	var started bool
+	err = connection.Unpack(res, &started)
+	if err != nil {
+		return err
+	}
+	if !started {
+		return fmt.Errorf("%w: %v", ErrPageNotStarted, p.Name())
+	}
+	return nil
+}
+
+// EndPage finishes the current page and moves it into the output tray.
+func (p *Printer) EndPage(ctx context.Context) error {
+	res, err := p.h.Call(ctx, "endPage")
+	if err != nil {
+		return err
+	}
+
+	var ended bool
+	err = connection.Unpack(res, &ended)
+	if err != nil {
+		return err
+	}
+	if !ended {
+		return fmt.Errorf("%w: %v", ErrOutputTrayFull, p.Name())
+	}
+	return nil
+}
+
+// Write writes text at the cursor position of the current page.
+func (p *Printer) Write(ctx context.Context, text string) error {
+	_, err := p.h.Call(ctx, "write", text)
+	return err
+}
+
+// SetCursorPos moves the cursor of the current page, the top left corner is 1, 1.
+func (p *Printer) SetCursorPos(ctx context.Context, x, y int) error {
+	_, err := p.h.Call(ctx, "setCursorPos", x, y)
+	return err
+}
+
+// CursorPos returns the cursor position on the current page.
+func (p *Printer) CursorPos(ctx context.Context) (x, y int, err error) {
+	res, err := p.h.Call(ctx, "getCursorPos")
+	if err != nil {
+		return 0, 0, err
+	}
+	err = connection.Unpack(res, &x, &y)
+	return x, y, err
+}
+
+// PageSize returns the size of the current page.
+func (p *Printer) PageSize(ctx context.Context) (width, height int, err error) {
+	res, err := p.h.Call(ctx, "getPageSize")
+	if err != nil {
+		return 0, 0, err
+	}
+	err = connection.Unpack(res, &width, &height)
+	return width, height, err
+}
+
+// SetPageTitle sets the title of the current page.
+func (p *Printer) SetPageTitle(ctx context.Context, title string) error {
+	_, err := p.h.Call(ctx, "setPageTitle", title)
+	return err
+}
+
+// InkLevel returns the amount of ink in the printer.
+func (p *Printer) InkLevel(ctx context.Context) (level int, err error) {
+	res, err := p.h.Call(ctx, "getInkLevel")
+	if err != nil {
+		return 0, err
+	}
+	err = connection.Unpack(res, &level)
+	return level, err
+}
+
+// PaperLevel returns the amount of paper in the printer.
+func (p *Printer) PaperLevel(ctx context.Context) (level int, err error) {
+	res, err := p.h.Call(ctx, "getPaperLevel")
+	if err != nil {
+		return 0, err
+	}
+	err = connection.Unpack(res, &level)
+	return level, err
+}
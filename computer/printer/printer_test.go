@@ -0,0 +1,102 @@
+package printer
+
+import (
+	"context"
+	"errors"
+	"github.com/m4schini/computercraft-go/test"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var printer = test.Peripheral("printer_0", []string{"printer"}, []string{"newPage", "endPage", "write", "setCursorPos", "getCursorPos", "getPageSize", "setPageTitle", "getInkLevel", "getPaperLevel"})
+
+func TestWordWrap(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		width    int
+		expected []string
+	}{
+		{name: "words", text: "the quick brown fox jumps", width: 10, expected: []string{"the quick", "brown fox", "jumps"}},
+		{name: "line breaks", text: "one\n\ntwo", width: 10, expected: []string{"one", "", "two"}},
+		{name: "long word", text: "a abcdefghijkl", width: 5, expected: []string{"a", "abcde", "fghij", "kl"}},
+		{name: "indentation", text: "  - item one", width: 8, expected: []string{"  - item", "one"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//act
+			actual := WordWrap(tt.text, tt.width)
+
+			//assert
+			if !reflect.DeepEqual(tt.expected, actual) {
+				t.Logf("expected: %q", tt.expected)
+				t.Logf("actual  : %q", actual)
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestPaginate(t *testing.T) {
+	//arrange
+	var expected = [][]string{{"a", "b"}, {"c"}}
+
+	//act
+	actual := Paginate([]string{"a", "b", "", "c"}, 2)
+
+	//assert
+	if !reflect.DeepEqual(expected, actual) {
+		t.Logf("expected: %q", expected)
+		t.Logf("actual  : %q", actual)
+		t.FailNow()
+	}
+}
+
+func TestFromMarkdown(t *testing.T) {
+	//arrange
+	markdown := "# Mining\n\nDig **down** until\nyou find [diamonds](https://example.com).\n\n* pickaxe\n* torches\n\n```\nturtle.dig()\n```"
+	var expected = "Mining\n\nDig down until you find diamonds.\n\n- pickaxe\n- torches\n\nturtle.dig()"
+
+	//act
+	actual := FromMarkdown(markdown)
+
+	//assert
+	if expected != actual {
+		t.Logf("expected: %q", expected)
+		t.Logf("actual  : %q", actual)
+		t.FailNow()
+	}
+}
+
+func TestPrinter_PrintDocument_outOfPaper(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{printer})
+	p, err := Wrap(context.TODO(), conn, "printer_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Reset()
+	conn.Respond(
+		[]any{10.0}, []any{1.0}, // ink, paper
+		[]any{true},            // newPage
+		[]any{10.0, 2.0},       // getPageSize
+		[]any{true},            // first page
+		[]any{9.0}, []any{0.0}, // ink, paper
+	)
+
+	//act
+	printed, err := p.PrintDocument(context.TODO(), "Notes", "the quick brown fox jumps")
+
+	//assert
+	commands := conn.Executed()
+	t.Logf("actual: %v, %v", printed, err)
+	if printed != 1 || !errors.Is(err, ErrOutOfPaper) {
+		t.FailNow()
+	}
+	t.Logf("actual: %v", commands[4])
+	if !strings.HasSuffix(commands[4], `("printer_0", "Notes (1/2)", {"the quick", "brown fox"})`) {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,64 @@
+package computer
+
+import "errors"
+
+// Failure reasons of the turtle API
+var (
+	ErrMovementObstructed  = errors.New("movement obstructed")
+	ErrOutOfFuel           = errors.New("out of fuel")
+	ErrTooHigh             = errors.New("too high to move")
+	ErrTooLow              = errors.New("too low to move")
+	ErrCannotLeaveWorld    = errors.New("cannot leave the world")
+	ErrWorldBorder         = errors.New("cannot pass the world border")
+	ErrProtectedArea       = errors.New("cannot enter protected area")
+	ErrNothingToDig        = errors.New("nothing to dig here")
+	ErrUnbreakable         = errors.New("cannot break unbreakable block")
+	ErrProtectedBlock      = errors.New("cannot break protected block")
+	ErrNoTool              = errors.New("no tool to dig with")
+	ErrCannotPlace         = errors.New("cannot place block here")
+	ErrNoItemsToPlace      = errors.New("no items to place")
+	ErrNoItemsToDrop       = errors.New("no items to drop")
+	ErrNoItemsToTake       = errors.New("no items to take")
+	ErrNoSpace             = errors.New("no space for items")
+	ErrNothingToAttack     = errors.New("nothing to attack here")
+	ErrNoItemsToCombust    = errors.New("no items to combust")
+	ErrItemsNotCombustible = errors.New("items not combustible")
+	ErrNoMatchingRecipes   = errors.New("no matching recipes")
+	ErrNotValidUpgrade     = errors.New("not a valid upgrade")
+)
+
+// reasons maps the reasons returned by the turtle API to errors
+var reasons = map[string]error{
+	"Movement obstructed":            ErrMovementObstructed,
+	"Out of fuel":                    ErrOutOfFuel,
+	"Too high to move":               ErrTooHigh,
+	"Too low to move":                ErrTooLow,
+	"Cannot leave the world":         ErrCannotLeaveWorld,
+	"Cannot leave loaded world":      ErrCannotLeaveWorld,
+	"Cannot pass the world border":   ErrWorldBorder,
+	"Cannot enter protected area":    ErrProtectedArea,
+	"Nothing to dig here":            ErrNothingToDig,
+	"Cannot break unbreakable block": ErrUnbreakable,
+	"Cannot break protected block":   ErrProtectedBlock,
+	"No tool to dig with":            ErrNoTool,
+	"Cannot place block here":        ErrCannotPlace,
+	"Cannot place item here":         ErrCannotPlace,
+	"Cannot place in protected area": ErrProtectedArea,
+	"No items to place":              ErrNoItemsToPlace,
+	"No items to drop":               ErrNoItemsToDrop,
+	"No items to take":               ErrNoItemsToTake,
+	"No space for items":             ErrNoSpace,
+	"Nothing to attack here":         ErrNothingToAttack,
+	"No tool to attack with":         ErrNoTool,
+	"No items to combust":            ErrNoItemsToCombust,
+	"Items not combustible":          ErrItemsNotCombustible,
+	"No matching recipes":            ErrNoMatchingRecipes,
+	"Not a valid upgrade":            ErrNotValidUpgrade,
+}
+
+// ParseReason returns the error for a reason returned by the turtle API (e.g. ErrMovementObstructed
+// for "Movement obstructed"), or nil if the reason is unknown. Failed actions return a
+// *connection.ActionError, that wraps the parsed reason.
+func ParseReason(reason string) error {
+	return reasons[reason]
+}
@@ -0,0 +1,175 @@
+package rednet
+
+import (
+	"context"
+	"fmt"
+	"github.com/m4schini/computercraft-go/computer/peripheral"
+	"github.com/m4schini/computercraft-go/connection"
+)
+
+const (
+	ModemPeripheralType = "modem"
+
+	// ModemMessageEvent is queued whenever a modem receives a message on an open channel
+	ModemMessageEvent = "modem_message"
+)
+
+// ModemMessage is a message received on a modem channel
+type ModemMessage struct {
+	Side         string
+	Channel      int
+	ReplyChannel int
+	Payload      any
+	Distance     float64
+}
+
+// Decode decodes the payload of the message into out
+func (m ModemMessage) Decode(out any) error {
+	return connection.Decode(m.Payload, out)
+}
+
+func modemMessageFromEvent(event connection.Event) (ModemMessage, error) {
+	var m ModemMessage
+	err := event.Arg(0, &m.Side)
+	if err != nil {
+		return m, err
+	}
+	err = event.Arg(1, &m.Channel)
+	if err != nil {
+		return m, err
+	}
+	err = event.Arg(2, &m.ReplyChannel)
+	if err != nil {
+		return m, err
+	}
+	if len(event.Args) > 3 {
+		m.Payload = event.Args[3]
+	}
+	// the distance is nil for messages received across dimensions
+	_ = event.Arg(4, &m.Distance)
+	return m, nil
+}
+
+// Modem is a wrapped modem peripheral, for sending raw messages on channels.
+type Modem struct {
+	h    *peripheral.Handle
+	conn connection.Connection
+}
+
+// WrapModem returns the modem with the given name (or on the given side).
+func WrapModem(ctx context.Context, conn connection.Connection, name string) (*Modem, error) {
+	h, err := peripheral.Wrap(ctx, conn, name)
+	if err != nil {
+		return nil, err
+	}
+	if !h.HasType(ModemPeripheralType) {
+		return nil, fmt.Errorf("peripheral %v is not a %v", h.Name(), ModemPeripheralType)
+	}
+	return &Modem{h: h, conn: conn}, nil
+}
+
+// FindModems returns all attached modems.
+func FindModems(ctx context.Context, conn connection.Connection) ([]*Modem, error) {
+	handles, err := peripheral.Find(ctx, conn, ModemPeripheralType)
+	if err != nil {
+		return nil, err
+	}
+
+	modems := make([]*Modem, len(handles))
+	for i, h := range handles {
+		modems[i] = &Modem{h: h, conn: conn}
+	}
+	return modems, nil
+}
+
+// Name returns the peripheral name of the modem
+func (m *Modem) Name() string {
+	return m.h.Name()
+}
+
+// Open opens a channel (0 to 65535) to receive messages on it.
+func (m *Modem) Open(ctx context.Context, channel int) error {
+	_, err := m.h.Call(ctx, "open", channel)
+	return err
+}
+
+// IsOpen checks if a channel is open.
+func (m *Modem) IsOpen(ctx context.Context, channel int) (open bool, err error) {
+	res, err := m.h.Call(ctx, "isOpen", channel)
+	if err != nil {
+		return false, err
+	}
+	err = connection.Unpack(res, &open)
+	return open, err
+}
+
+// Close closes a channel.
+func (m *Modem) Close(ctx context.Context, channel int) error {
+	_, err := m.h.Call(ctx, "close", channel)
+	return err
+}
+
+// CloseAll closes all channels.
+func (m *Modem) CloseAll(ctx context.Context) error {
+	_, err := m.h.Call(ctx, "closeAll")
+	return err
+}
+
+// Transmit sends a message on a channel. The channel doesn't need to be open.
+func (m *Modem) Transmit(ctx context.Context, channel, replyChannel int, payload any) error {
+	_, err := m.h.Call(ctx, "transmit", channel, replyChannel, payload)
+	return err
+}
+
+// IsWireless checks if this is a wireless (or ender) modem.
+func (m *Modem) IsWireless(ctx context.Context) (wireless bool, err error) {
+	res, err := m.h.Call(ctx, "isWireless")
+	if err != nil {
+		return false, err
+	}
+	err = connection.Unpack(res, &wireless)
+	return wireless, err
+}
+
+// Messages returns a channel receiving all messages this modem receives on the given
+// channel until ctx is done. The channel has to be opened with Open first.
+func (m *Modem) Messages(ctx context.Context, channel int) (<-chan ModemMessage, error) {
+	events, err := m.conn.Subscribe(ctx, ModemMessageEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(chan ModemMessage)
+	go func() {
+		defer close(messages)
+		for event := range events {
+			msg, err := modemMessageFromEvent(event)
+			if err != nil || msg.Side != m.Name() || msg.Channel != channel {
+				continue
+			}
+			select {
+			case messages <- msg:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return messages, nil
+}
+
+// Receive waits for the next message on the given channel.
+func (m *Modem) Receive(ctx context.Context, channel int) (ModemMessage, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	messages, err := m.Messages(ctx, channel)
+	if err != nil {
+		return ModemMessage{}, err
+	}
+
+	msg, ok := <-messages
+	if !ok {
+		return ModemMessage{}, ctx.Err()
+	}
+	return msg, nil
+}
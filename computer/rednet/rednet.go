@@ -0,0 +1,184 @@
+package rednet
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/m4schini/computercraft-go/connection"
+)
+
+const (
+	ModuleName = "rednet"
+
+	// MessageEvent is queued by rednet whenever a message is received
+	MessageEvent = "rednet_message"
+
+	// BroadcastChannel is the channel used by rednet to send broadcast messages
+	BroadcastChannel = 65535
+	// RepeatChannel is the channel used by rednet repeaters
+	RepeatChannel = 65533
+)
+
+// Message is a message received via rednet
+type Message struct {
+	Sender   int
+	Content  any
+	Protocol string
+}
+
+// Decode decodes the content of the message into out
+func (m Message) Decode(out any) error {
+	return connection.Decode(m.Content, out)
+}
+
+func messageFromEvent(event connection.Event) (Message, error) {
+	var m Message
+	err := event.Arg(0, &m.Sender)
+	if err != nil {
+		return m, err
+	}
+	if len(event.Args) > 1 {
+		m.Content = event.Args[1]
+	}
+	// messages without protocol have a nil protocol
+	_ = event.Arg(2, &m.Protocol)
+	return m, nil
+}
+
+func call(ctx context.Context, conn connection.Connection, function string, args ...any) ([]any, error) {
+	arguments, err := connection.LuaArgs(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := conn.Execute(ctx, fmt.Sprintf("%v.%v(%v)", ModuleName, function, arguments))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+	return res, nil
+}
+
+func optional(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// Open opens a modem with the given peripheral name, allowing it to send and receive messages over rednet.
+func Open(ctx context.Context, conn connection.Connection, modem string) error {
+	_, err := call(ctx, conn, "open", modem)
+	return err
+}
+
+// Close closes a modem with the given peripheral name, or all modems if modem is empty.
+func Close(ctx context.Context, conn connection.Connection, modem string) error {
+	_, err := call(ctx, conn, "close", optional(modem))
+	return err
+}
+
+// IsOpen checks if a modem is open, or if any modem is open if modem is empty.
+func IsOpen(ctx context.Context, conn connection.Connection, modem string) (open bool, err error) {
+	res, err := call(ctx, conn, "isOpen", optional(modem))
+	if err != nil {
+		return false, err
+	}
+	err = connection.Unpack(res, &open)
+	return open, err
+}
+
+// Send sends a message to another computer. The protocol may be empty. It returns
+// whether the message was sent, which doesn't guarantee it was received.
+func Send(ctx context.Context, conn connection.Connection, recipient int, message any, protocol string) (sent bool, err error) {
+	res, err := call(ctx, conn, "send", recipient, message, optional(protocol))
+	if err != nil {
+		return false, err
+	}
+	err = connection.Unpack(res, &sent)
+	return sent, err
+}
+
+// Broadcast sends a message to all computers in range. The protocol may be empty.
+func Broadcast(ctx context.Context, conn connection.Connection, message any, protocol string) error {
+	_, err := call(ctx, conn, "broadcast", message, optional(protocol))
+	return err
+}
+
+// Messages returns a channel receiving all rednet messages with the given protocol
+// (or all messages, if protocol is empty) until ctx is done. The messages are
+// taken from the event stream, so waiting does not block other commands.
+func Messages(ctx context.Context, conn connection.Connection, protocol string) (<-chan Message, error) {
+	events, err := conn.Subscribe(ctx, MessageEvent)
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make(chan Message)
+	go func() {
+		defer close(messages)
+		for event := range events {
+			m, err := messageFromEvent(event)
+			if err != nil || (protocol != "" && m.Protocol != protocol) {
+				continue
+			}
+			select {
+			case messages <- m:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return messages, nil
+}
+
+// Receive waits for the next rednet message with the given protocol (or any
+// message, if protocol is empty). Use a context with a timeout to limit waiting.
+func Receive(ctx context.Context, conn connection.Connection, protocol string) (Message, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	messages, err := Messages(ctx, conn, protocol)
+	if err != nil {
+		return Message{}, err
+	}
+
+	m, ok := <-messages
+	if !ok {
+		return Message{}, ctx.Err()
+	}
+	return m, nil
+}
+
+// Host registers the computer under a hostname for a protocol, so it can be found with Lookup.
+func Host(ctx context.Context, conn connection.Connection, protocol, hostname string) error {
+	_, err := call(ctx, conn, "host", protocol, hostname)
+	return err
+}
+
+// Unhost stops hosting a protocol.
+func Unhost(ctx context.Context, conn connection.Connection, protocol string) error {
+	_, err := call(ctx, conn, "unhost", protocol)
+	return err
+}
+
+// Lookup searches the network for computers hosting a protocol, optionally with a
+// specific hostname. It returns the ids of all found computers. The search takes
+// about two seconds on the computer.
+func Lookup(ctx context.Context, conn connection.Connection, protocol, hostname string) ([]int, error) {
+	arguments, err := connection.LuaArgs(protocol, optional(hostname))
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := conn.Execute(ctx, fmt.Sprintf("{%v.lookup(%v)}", ModuleName, arguments))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+	if len(res) < 1 {
+		return nil, connection.RpcError(errors.New("unexpected data length"))
+	}
+
+	var ids []int
+	err = connection.Decode(res[0], &ids)
+	return ids, err
+}
@@ -0,0 +1,49 @@
+package rednet
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/test"
+	"testing"
+)
+
+func TestMessages(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	messages, err := Messages(ctx, conn, "mining")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//act
+	conn.Emit(MessageEvent, float64(3), "ignored", nil)
+	conn.Emit(MessageEvent, float64(4), "ignored", "chat")
+	conn.Emit(MessageEvent, float64(7), map[string]any{"x": float64(12)}, "mining")
+	m := <-messages
+
+	//assert
+	var content struct {
+		X int `lua:"x"`
+	}
+	err = m.Decode(&content)
+	t.Logf("actual: %+v", m)
+	if err != nil || m.Sender != 7 || m.Protocol != "mining" || content.X != 12 {
+		t.FailNow()
+	}
+}
+
+func TestLookup(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{[]any{float64(2), float64(9)}})
+
+	//act
+	ids, err := Lookup(context.TODO(), conn, "mining", "")
+
+	//assert
+	t.Logf("command: %v", conn.Executed()[0])
+	if err != nil || len(ids) != 2 || ids[1] != 9 || conn.Executed()[0] != `{rednet.lookup("mining", nil)}` {
+		t.FailNow()
+	}
+}
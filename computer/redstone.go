@@ -2,11 +2,22 @@ package computer
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"github.com/m4schini/computercraft-go/computer/colors"
 	"github.com/m4schini/computercraft-go/connection"
 )
 
+const (
+	RedstoneModuleName = "redstone"
+
+	// RedstoneEvent is queued whenever any redstone input changes
+	RedstoneEvent = "redstone"
+)
+
 type Redstone interface {
+	//Sides returns the names of all sides of the computer.
+	Sides(ctx context.Context) ([]Side, error)
 	//SetOutput turns the redstone signal of a specific side on or off.
 	SetOutput(ctx context.Context, side Side, on bool) error
 	//SetAnalogOutput sets the redstone signal strength for a specific side.
@@ -14,25 +25,257 @@ type Redstone interface {
 	//Output gets the current redstone output of a specific side.
 	Output(ctx context.Context, side Side) (bool, int, error)
 	//Input gets the current redstone input of a specific side.
-	Input(ctx context.Context, side Side) (int, error)
+	Input(ctx context.Context, side Side) (bool, int, error)
+
+	//SetBundledOutput sets the colors of a bundled cable connected to a specific side.
+	SetBundledOutput(ctx context.Context, side Side, output colors.Color) error
+	//BundledOutput gets the colors a bundled cable connected to a specific side is set to.
+	BundledOutput(ctx context.Context, side Side) (colors.Color, error)
+	//BundledInput gets the colors that are on in a bundled cable connected to a specific side.
+	BundledInput(ctx context.Context, side Side) (colors.Color, error)
+	//TestBundledInput checks if all given colors are on in a bundled cable connected to a specific side.
+	TestBundledInput(ctx context.Context, side Side, mask colors.Color) (bool, error)
+
+	//WatchInput returns a channel receiving every change of a redstone input.
+	WatchInput(ctx context.Context) (<-chan RedstoneChange, error)
+}
+
+// RedstoneChange is the change of the redstone input of a single side
+type RedstoneChange struct {
+	Side            Side
+	Previous        int
+	Current         int
+	PreviousBundled colors.Color
+	CurrentBundled  colors.Color
+}
+
+// Rising checks if the signal was turned on
+func (r RedstoneChange) Rising() bool {
+	return r.Previous == 0 && r.Current > 0
+}
+
+// Falling checks if the signal was turned off
+func (r RedstoneChange) Falling() bool {
+	return r.Previous > 0 && r.Current == 0
+}
+
+func redstoneCall(ctx context.Context, conn connection.Connection, function string, args ...any) ([]any, error) {
+	arguments, err := connection.LuaArgs(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := conn.Execute(ctx, fmt.Sprintf("%v.%v(%v)", RedstoneModuleName, function, arguments))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+	return res, nil
+}
+
+func RedstoneSides(conn connection.Connection, ctx context.Context) ([]Side, error) {
+	res, err := redstoneCall(ctx, conn, "getSides")
+	if err != nil {
+		return nil, err
+	}
+	if len(res) < 1 {
+		return nil, connection.RpcError(errors.New("unexpected data length"))
+	}
+
+	var sides []Side
+	err = connection.Decode(res[0], &sides)
+	return sides, err
 }
 
 func SetOutput(conn connection.Connection, ctx context.Context, side Side, on bool) error {
-	_, err := conn.Execute(ctx, fmt.Sprintf("")) //TODO
+	_, err := redstoneCall(ctx, conn, "setOutput", side, on)
 	return err
 }
 
 func SetAnalogOutput(conn connection.Connection, ctx context.Context, side Side, value int) error {
-	_, err := conn.Execute(ctx, fmt.Sprintf("")) //TODO
+	if value < 0 || value > 15 {
+		return fmt.Errorf("redstone signal strength %v out of range [0, 15]", value)
+	}
+	_, err := redstoneCall(ctx, conn, "setAnalogOutput", side, value)
 	return err
 }
 
 func Output(conn connection.Connection, ctx context.Context, side Side) (bool, int, error) {
-	_, err := conn.Execute(ctx, fmt.Sprintf("")) //TODO
-	return false, 0, err
+	res, err := conn.Execute(ctx, fmt.Sprintf("%[1]v.getOutput(%[2]v), %[1]v.getAnalogOutput(%[2]v)",
+		RedstoneModuleName, connection.LuaString(string(side))))
+	if err != nil {
+		return false, 0, connection.RpcError(err)
+	}
+
+	var on bool
+	var strength int
+	err = connection.Unpack(res, &on, &strength)
+	return on, strength, err
+}
+
+func Input(conn connection.Connection, ctx context.Context, side Side) (bool, int, error) {
+	res, err := conn.Execute(ctx, fmt.Sprintf("%[1]v.getInput(%[2]v), %[1]v.getAnalogInput(%[2]v)",
+		RedstoneModuleName, connection.LuaString(string(side))))
+	if err != nil {
+		return false, 0, connection.RpcError(err)
+	}
+
+	var on bool
+	var strength int
+	err = connection.Unpack(res, &on, &strength)
+	return on, strength, err
+}
+
+func SetBundledOutput(conn connection.Connection, ctx context.Context, side Side, output colors.Color) error {
+	_, err := redstoneCall(ctx, conn, "setBundledOutput", side, output)
+	return err
+}
+
+func BundledOutput(conn connection.Connection, ctx context.Context, side Side) (colors.Color, error) {
+	res, err := redstoneCall(ctx, conn, "getBundledOutput", side)
+	if err != nil {
+		return 0, err
+	}
+
+	var output colors.Color
+	err = connection.Unpack(res, &output)
+	return output, err
+}
+
+func BundledInput(conn connection.Connection, ctx context.Context, side Side) (colors.Color, error) {
+	res, err := redstoneCall(ctx, conn, "getBundledInput", side)
+	if err != nil {
+		return 0, err
+	}
+
+	var input colors.Color
+	err = connection.Unpack(res, &input)
+	return input, err
+}
+
+func TestBundledInput(conn connection.Connection, ctx context.Context, side Side, mask colors.Color) (bool, error) {
+	res, err := redstoneCall(ctx, conn, "testBundledInput", side, mask)
+	if err != nil {
+		return false, err
+	}
+
+	var on bool
+	err = connection.Unpack(res, &on)
+	return on, err
+}
+
+type redstoneInput struct {
+	Analog  int          `lua:"analog"`
+	Bundled colors.Color `lua:"bundled"`
+}
+
+func redstoneInputs(conn connection.Connection, ctx context.Context) (map[Side]redstoneInput, error) {
+	res, err := conn.Execute(ctx, fmt.Sprintf(
+		"(function(rs) local r = {} for _, side in ipairs(rs.getSides()) do r[side] = {analog = rs.getAnalogInput(side), bundled = rs.getBundledInput(side)} end return r end)(%v)",
+		RedstoneModuleName))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+
+	var inputs map[Side]redstoneInput
+	err = connection.Unpack(res, &inputs)
+	return inputs, err
+}
+
+// WatchRedstone returns a channel receiving every change of a redstone input (including
+// bundled cables) until ctx is done. The inputs of all sides are compared whenever
+// the computer queues a redstone event.
+func WatchRedstone(conn connection.Connection, ctx context.Context) (<-chan RedstoneChange, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	events, err := conn.Subscribe(ctx, RedstoneEvent)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	previous, err := redstoneInputs(conn, ctx)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	changes := make(chan RedstoneChange)
+	go func() {
+		defer cancel()
+		defer close(changes)
+		for range events {
+			current, err := redstoneInputs(conn, ctx)
+			if err != nil {
+				continue
+			}
+
+			for side, input := range current {
+				before := previous[side]
+				if input == before {
+					continue
+				}
+				select {
+				case changes <- RedstoneChange{
+					Side:            side,
+					Previous:        before.Analog,
+					Current:         input.Analog,
+					PreviousBundled: before.Bundled,
+					CurrentBundled:  input.Bundled,
+				}:
+				case <-ctx.Done():
+					return
+				}
+			}
+			previous = current
+		}
+	}()
+	return changes, nil
+}
+
+type redstone struct {
+	conn connection.Connection
+}
+
+// NewRedstone returns the redstone api of a computer (or turtle)
+func NewRedstone(conn connection.Connection) Redstone {
+	return &redstone{conn: conn}
+}
+
+func (r *redstone) Sides(ctx context.Context) ([]Side, error) {
+	return RedstoneSides(r.conn, ctx)
+}
+
+func (r *redstone) SetOutput(ctx context.Context, side Side, on bool) error {
+	return SetOutput(r.conn, ctx, side, on)
+}
+
+func (r *redstone) SetAnalogOutput(ctx context.Context, side Side, value int) error {
+	return SetAnalogOutput(r.conn, ctx, side, value)
+}
+
+func (r *redstone) Output(ctx context.Context, side Side) (bool, int, error) {
+	return Output(r.conn, ctx, side)
+}
+
+func (r *redstone) Input(ctx context.Context, side Side) (bool, int, error) {
+	return Input(r.conn, ctx, side)
+}
+
+func (r *redstone) SetBundledOutput(ctx context.Context, side Side, output colors.Color) error {
+	return SetBundledOutput(r.conn, ctx, side, output)
+}
+
+func (r *redstone) BundledOutput(ctx context.Context, side Side) (colors.Color, error) {
+	return BundledOutput(r.conn, ctx, side)
+}
+
+func (r *redstone) BundledInput(ctx context.Context, side Side) (colors.Color, error) {
+	return BundledInput(r.conn, ctx, side)
+}
+
+func (r *redstone) TestBundledInput(ctx context.Context, side Side, mask colors.Color) (bool, error) {
+	return TestBundledInput(r.conn, ctx, side, mask)
 }
 
-func Input(conn connection.Connection, ctx context.Context, side Side) (int, error) {
-	_, err := conn.Execute(ctx, fmt.Sprintf("")) //TODO
-	return 0, err
+func (r *redstone) WatchInput(ctx context.Context) (<-chan RedstoneChange, error) {
+	return WatchRedstone(r.conn, ctx)
 }
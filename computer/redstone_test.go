@@ -0,0 +1,56 @@
+package computer
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/computer/colors"
+	"github.com/m4schini/computercraft-go/test"
+	"testing"
+)
+
+func TestRedstone_SetBundledOutput(t *testing.T) {
+	//arrange
+	var expected = `redstone.setBundledOutput("back", 16385)`
+	conn := &test.ConnectionMock{}
+	r := NewRedstone(conn)
+
+	//act
+	err := r.SetBundledOutput(context.TODO(), SideBack, colors.Combine(colors.White, colors.Red))
+
+	//assert
+	t.Logf("expected: %v", expected)
+	t.Logf("  actual: %v", conn.Executed()[0])
+	if err != nil || conn.Executed()[0] != expected {
+		t.FailNow()
+	}
+}
+
+func TestRedstone_WatchInput(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond(
+		[]any{map[string]any{
+			"left":  map[string]any{"analog": float64(0), "bundled": float64(0)},
+			"right": map[string]any{"analog": float64(15), "bundled": float64(0)},
+		}},
+		[]any{map[string]any{
+			"left":  map[string]any{"analog": float64(7), "bundled": float64(0)},
+			"right": map[string]any{"analog": float64(15), "bundled": float64(0)},
+		}},
+	)
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	changes, err := NewRedstone(conn).WatchInput(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//act
+	conn.Emit(RedstoneEvent)
+	change := <-changes
+
+	//assert
+	t.Logf("actual: %+v", change)
+	if change.Side != SideLeft || change.Current != 7 || !change.Rising() {
+		t.FailNow()
+	}
+}
@@ -1,21 +1,24 @@
 package computer
 
-import "context"
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/computer/settings"
+)
 
-type SettingsOption struct {
-	description string
-	defaultVal  string
-	typeVal     string
-}
+// SettingsOption describes a setting, see settings.Option
+type SettingsOption = settings.Option
 
 type Settings interface {
 	Define(ctx context.Context, name string, option ...SettingsOption) error
 	Undefine(ctx context.Context, name string) error
-	Set(ctx context.Context, name, value string) error
+	Set(ctx context.Context, name string, value any) error
 	Unset(ctx context.Context, name string) error
-	Get(ctx context.Context, name string) (string, error)
+	Get(ctx context.Context, name string) (any, error)
+	GetDetails(ctx context.Context, name string) (settings.Details, error)
 	Clear(ctx context.Context) error
 	Names(ctx context.Context) ([]string, error)
 	Load(ctx context.Context, path string) (bool, error)
 	Save(ctx context.Context, path string) (bool, error)
 }
+
+var _ Settings = (*settings.Settings)(nil)
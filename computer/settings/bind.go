@@ -0,0 +1,175 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/m4schini/computercraft-go/connection"
+	"reflect"
+	"strings"
+)
+
+// bindFunc defines all bound settings and returns their current values
+const bindFunc = `function(defs) local r = {} for _, d in ipairs(defs) do settings.define(d.name, d.option) r[d.name] = settings.get(d.name) end return r end`
+
+// Binding connects the fields of a struct to settings of a computer, see Bind.
+type Binding struct {
+	conn   connection.Connection
+	fields []boundField
+}
+
+type boundField struct {
+	name  string
+	value reflect.Value
+	// last is the lua literal of the value, when it was last read or written
+	last string
+}
+
+// Bind maps the fields of the struct pointed to by cfg to settings. Fields are bound with a
+// `setting:"name"` tag and can be described with a `description:"..."` tag. The values the
+// fields have when Bind is called are used as the defaults of the settings.
+//
+// All settings are defined, and the fields are set to the current values of the settings.
+// Changes to the struct are written back with Binding.Commit.
+//
+//	type MinerConfig struct {
+//		Home    [3]int `setting:"miner.home" description:"Position of the base"`
+//		MinFuel int    `setting:"miner.min_fuel" description:"Return home below this fuel level"`
+//	}
+func Bind(ctx context.Context, conn connection.Connection, cfg any) (*Binding, error) {
+	rv := reflect.ValueOf(cfg)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cfg must be a non-nil pointer to a struct, got %T", cfg)
+	}
+
+	b := &Binding{conn: conn}
+	var definitions []string
+	for i := 0; i < rv.Elem().NumField(); i++ {
+		field := rv.Elem().Type().Field(i)
+		name := field.Tag.Get("setting")
+		if name == "" || name == "-" {
+			continue
+		}
+		if !field.IsExported() {
+			return nil, fmt.Errorf("field %v bound to %v is not exported", field.Name, name)
+		}
+
+		value := rv.Elem().Field(i)
+		settingType, err := typeOf(value.Type())
+		if err != nil {
+			return nil, fmt.Errorf("field %v bound to %v: %w", field.Name, name, err)
+		}
+
+		option := Option{
+			Description: field.Tag.Get("description"),
+			Default:     value.Interface(),
+			Type:        settingType,
+		}
+		definition, err := connection.LuaValue(map[string]any{"name": name, "option": option.table()})
+		if err != nil {
+			return nil, fmt.Errorf("field %v bound to %v: %w", field.Name, name, err)
+		}
+		definitions = append(definitions, definition)
+		b.fields = append(b.fields, boundField{name: name, value: value})
+	}
+	if len(b.fields) == 0 {
+		return nil, errors.New("cfg has no fields with a setting tag")
+	}
+
+	res, err := conn.Execute(ctx, fmt.Sprintf("(%v)({%v})", bindFunc, strings.Join(definitions, ", ")))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+
+	var values map[string]any
+	err = connection.Unpack(res, &values)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range b.fields {
+		f := &b.fields[i]
+		if value, ok := values[f.name]; ok && value != nil {
+			err = connection.Decode(value, f.value.Addr().Interface())
+			if err != nil {
+				return nil, fmt.Errorf("setting %v: %w", f.name, err)
+			}
+		}
+		f.last, err = connection.LuaValue(f.value.Interface())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// typeOf returns the setting type of values of a go type
+func typeOf(t reflect.Type) (Type, error) {
+	switch t.Kind() {
+	case reflect.Bool:
+		return TypeBoolean, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return TypeNumber, nil
+	case reflect.String:
+		return TypeString, nil
+	case reflect.Slice, reflect.Array, reflect.Map, reflect.Struct:
+		return TypeTable, nil
+	default:
+		return "", fmt.Errorf("%w: %v cannot be stored in a setting", connection.UnexpectedDatatypeErr, t)
+	}
+}
+
+// Changed returns the names of all settings whose fields were changed since they were last read or written.
+func (b *Binding) Changed() ([]string, error) {
+	var changed []string
+	for _, f := range b.fields {
+		current, err := connection.LuaValue(f.value.Interface())
+		if err != nil {
+			return nil, err
+		}
+		if current != f.last {
+			changed = append(changed, f.name)
+		}
+	}
+	return changed, nil
+}
+
+// Commit writes all changed fields back to their settings and saves the settings to ".settings",
+// so they survive a reboot. It returns false if the settings could not be saved.
+func (b *Binding) Commit(ctx context.Context) (bool, error) {
+	var statements []string
+	var current []string
+	for _, f := range b.fields {
+		value, err := connection.LuaValue(f.value.Interface())
+		if err != nil {
+			return false, err
+		}
+		current = append(current, value)
+		if value == f.last {
+			continue
+		}
+
+		if value == "nil" {
+			statements = append(statements, fmt.Sprintf("%v.unset(%v)", ModuleName, connection.LuaString(f.name)))
+		} else {
+			statements = append(statements, fmt.Sprintf("%v.set(%v, %v)", ModuleName, connection.LuaString(f.name), value))
+		}
+	}
+	if len(statements) == 0 {
+		return true, nil
+	}
+
+	res, err := b.conn.Execute(ctx, fmt.Sprintf("(function() %v return %v.save() end)()", strings.Join(statements, " "), ModuleName))
+	if err != nil {
+		return false, connection.RpcError(err)
+	}
+	for i := range b.fields {
+		b.fields[i].last = current[i]
+	}
+
+	var saved bool
+	err = connection.Unpack(res, &saved)
+	return saved, err
+}
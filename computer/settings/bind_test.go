@@ -0,0 +1,66 @@
+package settings
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/test"
+	"strings"
+	"testing"
+)
+
+type minerConfig struct {
+	Home    [3]int `setting:"miner.home" description:"Position of the base"`
+	MinFuel int    `setting:"miner.min_fuel" description:"Return home below this fuel level"`
+	Verbose bool   `setting:"miner.verbose"`
+	runs    int
+}
+
+func TestBind(t *testing.T) {
+	//arrange
+	var expectedDefinition = `{["name"] = "miner.min_fuel", ["option"] = {["default"] = 200, ["description"] = "Return home below this fuel level", ["type"] = "number"}}`
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{map[string]any{
+		"miner.home":     []any{float64(10), float64(64), float64(-3)},
+		"miner.min_fuel": float64(500),
+		"miner.verbose":  false,
+	}})
+	cfg := minerConfig{MinFuel: 200}
+
+	//act
+	_, err := Bind(context.TODO(), conn, &cfg)
+
+	//assert
+	t.Logf("actual: %+v", cfg)
+	if err != nil || !strings.Contains(conn.Executed()[0], expectedDefinition) {
+		t.FailNow()
+	}
+	if cfg.Home != [3]int{10, 64, -3} || cfg.MinFuel != 500 || cfg.Verbose {
+		t.FailNow()
+	}
+}
+
+func TestBinding_Commit(t *testing.T) {
+	//arrange
+	var expected = `(function() settings.set("miner.verbose", true) return settings.save() end)()`
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{map[string]any{}}, []any{true})
+	cfg := minerConfig{MinFuel: 200}
+	b, err := Bind(context.TODO(), conn, &cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//act
+	cfg.Verbose = true
+	saved, err := b.Commit(context.TODO())
+
+	//assert
+	t.Logf("expected: %v", expected)
+	t.Logf("  actual: %v", conn.Executed()[1])
+	if err != nil || !saved || conn.Executed()[1] != expected {
+		t.FailNow()
+	}
+	changed, _ := b.Changed()
+	if len(changed) != 0 {
+		t.FailNow()
+	}
+}
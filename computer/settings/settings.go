@@ -0,0 +1,244 @@
+package settings
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/m4schini/computercraft-go/connection"
+)
+
+const (
+	ModuleName = "settings"
+)
+
+// Type is the type of the value of a setting, as checked by settings.set
+type Type string
+
+const (
+	TypeString  Type = "string"
+	TypeNumber  Type = "number"
+	TypeBoolean Type = "boolean"
+	TypeTable   Type = "table"
+)
+
+// Option describes a setting. All fields are optional.
+type Option struct {
+	Description string
+	Default     any
+	Type        Type
+}
+
+// table returns the option as the lua table expected by settings.define
+func (o Option) table() map[string]any {
+	t := make(map[string]any)
+	if o.Description != "" {
+		t["description"] = o.Description
+	}
+	if o.Default != nil {
+		t["default"] = o.Default
+	}
+	if o.Type != "" {
+		t["type"] = string(o.Type)
+	}
+	return t
+}
+
+// Details are the details of a setting, see settings.getDetails
+type Details struct {
+	Description string `lua:"description"`
+	Default     any    `lua:"default"`
+	Type        Type   `lua:"type"`
+	Value       any    `lua:"value"`
+	// Changed is true if a value has been set, even if it equals the default value
+	Changed bool `lua:"changed"`
+}
+
+func call(ctx context.Context, conn connection.Connection, function string, args ...any) ([]any, error) {
+	arguments, err := connection.LuaArgs(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := conn.Execute(ctx, fmt.Sprintf("%v.%v(%v)", ModuleName, function, arguments))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+	return res, nil
+}
+
+// Define defines a new setting, optionally with a description, default value and type.
+// Only the first option is used.
+func Define(ctx context.Context, conn connection.Connection, name string, option ...Option) error {
+	var o Option
+	if len(option) > 0 {
+		o = option[0]
+	}
+	_, err := call(ctx, conn, "define", name, o.table())
+	return err
+}
+
+// Undefine removes the definition of a setting. Its value is kept.
+func Undefine(ctx context.Context, conn connection.Connection, name string) error {
+	_, err := call(ctx, conn, "undefine", name)
+	return err
+}
+
+// Set sets the value of a setting. The value can be a bool, number, string or a
+// table (slice, map or struct). It fails if the setting was defined with another type.
+func Set(ctx context.Context, conn connection.Connection, name string, value any) error {
+	if value == nil {
+		return errors.New("value cannot be nil, use Unset instead")
+	}
+	_, err := call(ctx, conn, "set", name, value)
+	return err
+}
+
+// Unset removes the value of a setting, so its default value is used.
+func Unset(ctx context.Context, conn connection.Connection, name string) error {
+	_, err := call(ctx, conn, "unset", name)
+	return err
+}
+
+// Get returns the value of a setting, or nil if it is not set and has no default value.
+func Get(ctx context.Context, conn connection.Connection, name string) (any, error) {
+	res, err := call(ctx, conn, "get", name)
+	if err != nil {
+		return nil, err
+	}
+	if len(res) < 1 {
+		return nil, nil
+	}
+	return res[0], nil
+}
+
+// GetInto decodes the value of a setting into the value pointed to by out (see connection.Decode).
+func GetInto(ctx context.Context, conn connection.Connection, name string, out any) error {
+	value, err := Get(ctx, conn, name)
+	if err != nil {
+		return err
+	}
+	return connection.Decode(value, out)
+}
+
+// GetString returns the value of a string setting
+func GetString(ctx context.Context, conn connection.Connection, name string) (value string, err error) {
+	err = GetInto(ctx, conn, name, &value)
+	return value, err
+}
+
+// GetNumber returns the value of a number setting
+func GetNumber(ctx context.Context, conn connection.Connection, name string) (value float64, err error) {
+	err = GetInto(ctx, conn, name, &value)
+	return value, err
+}
+
+// GetBool returns the value of a boolean setting
+func GetBool(ctx context.Context, conn connection.Connection, name string) (value bool, err error) {
+	err = GetInto(ctx, conn, name, &value)
+	return value, err
+}
+
+// GetDetails returns the definition and the current value of a setting.
+func GetDetails(ctx context.Context, conn connection.Connection, name string) (Details, error) {
+	res, err := call(ctx, conn, "getDetails", name)
+	if err != nil {
+		return Details{}, err
+	}
+
+	var details Details
+	err = connection.Unpack(res, &details)
+	return details, err
+}
+
+// Clear removes the value of all settings.
+func Clear(ctx context.Context, conn connection.Connection) error {
+	_, err := call(ctx, conn, "clear")
+	return err
+}
+
+// Names returns the names of all defined settings and settings with a value.
+func Names(ctx context.Context, conn connection.Connection) ([]string, error) {
+	res, err := call(ctx, conn, "getNames")
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	err = connection.Unpack(res, &names)
+	return names, err
+}
+
+// Load loads settings from a file, or from ".settings" if path is empty. The loaded settings
+// are merged with the current settings. It returns false if the file could not be read.
+func Load(ctx context.Context, conn connection.Connection, path string) (bool, error) {
+	return fileAction(ctx, conn, "load", path)
+}
+
+// Save saves the current settings to a file, or to ".settings" if path is empty.
+// It returns false if the file could not be written.
+func Save(ctx context.Context, conn connection.Connection, path string) (bool, error) {
+	return fileAction(ctx, conn, "save", path)
+}
+
+func fileAction(ctx context.Context, conn connection.Connection, function, path string) (ok bool, err error) {
+	var arg any
+	if path != "" {
+		arg = path
+	}
+
+	res, err := call(ctx, conn, function, arg)
+	if err != nil {
+		return false, err
+	}
+	err = connection.Unpack(res, &ok)
+	return ok, err
+}
+
+// Settings is the settings api of a computer (or turtle, or pocket computer)
+type Settings struct {
+	conn connection.Connection
+}
+
+func New(conn connection.Connection) *Settings {
+	return &Settings{conn: conn}
+}
+
+func (s *Settings) Define(ctx context.Context, name string, option ...Option) error {
+	return Define(ctx, s.conn, name, option...)
+}
+
+func (s *Settings) Undefine(ctx context.Context, name string) error {
+	return Undefine(ctx, s.conn, name)
+}
+
+func (s *Settings) Set(ctx context.Context, name string, value any) error {
+	return Set(ctx, s.conn, name, value)
+}
+
+func (s *Settings) Unset(ctx context.Context, name string) error {
+	return Unset(ctx, s.conn, name)
+}
+
+func (s *Settings) Get(ctx context.Context, name string) (any, error) {
+	return Get(ctx, s.conn, name)
+}
+
+func (s *Settings) GetDetails(ctx context.Context, name string) (Details, error) {
+	return GetDetails(ctx, s.conn, name)
+}
+
+func (s *Settings) Clear(ctx context.Context) error {
+	return Clear(ctx, s.conn)
+}
+
+func (s *Settings) Names(ctx context.Context) ([]string, error) {
+	return Names(ctx, s.conn)
+}
+
+func (s *Settings) Load(ctx context.Context, path string) (bool, error) {
+	return Load(ctx, s.conn, path)
+}
+
+func (s *Settings) Save(ctx context.Context, path string) (bool, error) {
+	return Save(ctx, s.conn, path)
+}
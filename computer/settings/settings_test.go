@@ -0,0 +1,67 @@
+package settings
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/test"
+	"testing"
+)
+
+func TestDefine(t *testing.T) {
+	//arrange
+	var expected = `settings.define("miner.depth", {["default"] = 64, ["description"] = "How deep to mine", ["type"] = "number"})`
+	conn := &test.ConnectionMock{}
+
+	//act
+	err := Define(context.TODO(), conn, "miner.depth", Option{
+		Description: "How deep to mine",
+		Default:     64,
+		Type:        TypeNumber,
+	})
+
+	//assert
+	t.Logf("expected: %v", expected)
+	t.Logf("  actual: %v", conn.Executed()[0])
+	if err != nil || conn.Executed()[0] != expected {
+		t.FailNow()
+	}
+}
+
+func TestGet(t *testing.T) {
+	//arrange
+	var expected = `settings.get("miner.home")`
+	type position struct {
+		X int `lua:"x"`
+		Y int `lua:"y"`
+		Z int `lua:"z"`
+	}
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{map[string]any{"x": float64(10), "y": float64(64), "z": float64(-3)}})
+	var home position
+
+	//act
+	err := GetInto(context.TODO(), conn, "miner.home", &home)
+
+	//assert
+	t.Logf("expected: %v", expected)
+	t.Logf("  actual: %v %+v", conn.Executed()[0], home)
+	if err != nil || conn.Executed()[0] != expected || home != (position{X: 10, Y: 64, Z: -3}) {
+		t.FailNow()
+	}
+}
+
+func TestLoad_defaultPath(t *testing.T) {
+	//arrange
+	var expected = `settings.load(nil)`
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{false})
+
+	//act
+	ok, err := Load(context.TODO(), conn, "")
+
+	//assert
+	t.Logf("expected: %v", expected)
+	t.Logf("  actual: %v", conn.Executed()[0])
+	if err != nil || ok || conn.Executed()[0] != expected {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,301 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/m4schini/computercraft-go/connection"
+	"sort"
+	"time"
+)
+
+const (
+	ModuleName           = "shell"
+	MultishellModuleName = "multishell"
+
+	// ExitEvent is emitted by the runtime when a program started with Start stops
+	ExitEvent = "ccgo_exit"
+
+	// StopTimeout limits how long Run waits for a program to be stopped, after its ctx is done
+	StopTimeout = 5 * time.Second
+)
+
+var (
+	ErrMultishellNotAvailable = errors.New("multishell is not available")
+	ErrNoSuchProcess          = errors.New("no such process")
+	ErrNoSuchProgram          = errors.New("no such program")
+)
+
+// Result is the result of a program
+type Result struct {
+	// Success is false if the program failed, e.g. with an error or because it was stopped
+	Success bool
+	// Output is the text the program wrote to its terminal
+	Output string
+}
+
+// Process is a program running in the background of a computer
+type Process struct {
+	PID int
+
+	conn   connection.Connection
+	done   chan struct{}
+	result Result
+	cancel context.CancelFunc
+}
+
+// Start runs a program with the given arguments in the background, like shell.execute.
+// The program runs in its own (invisible) terminal, its output is captured. Other commands
+// can be executed on the connection while the program is running. The process is only
+// tracked until ctx is done.
+func Start(ctx context.Context, conn connection.Connection, program string, args ...string) (*Process, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	events, err := conn.Subscribe(ctx, ExitEvent)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	arguments := make([]any, 0, len(args)+1)
+	arguments = append(arguments, program)
+	for _, arg := range args {
+		arguments = append(arguments, arg)
+	}
+	lua, err := connection.LuaArgs(arguments...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	res, err := conn.Execute(ctx, fmt.Sprintf("%v.run(%v)", connection.RuntimeModuleName, lua))
+	if err != nil {
+		cancel()
+		return nil, connection.RpcError(err)
+	}
+
+	p := &Process{conn: conn, done: make(chan struct{}), cancel: cancel}
+	err = connection.Unpack(res, &p.PID)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	go func() {
+		defer cancel()
+		for event := range events {
+			var pid int
+			if event.Arg(0, &pid) != nil || pid != p.PID {
+				continue
+			}
+			_ = event.Arg(1, &p.result.Success)
+			_ = event.Arg(2, &p.result.Output)
+			close(p.done)
+			return
+		}
+	}()
+	return p, nil
+}
+
+// Run runs a program like Start and waits until it stops. If ctx is done before, the program is stopped.
+func Run(ctx context.Context, conn connection.Connection, program string, args ...string) (Result, error) {
+	p, err := Start(ctx, conn, program, args...)
+	if err != nil {
+		return Result{}, err
+	}
+
+	result, err := p.Wait(ctx)
+	if err != nil {
+		stopCtx, cancel := context.WithTimeout(context.Background(), StopTimeout)
+		defer cancel()
+		_ = p.Stop(stopCtx)
+	}
+	return result, err
+}
+
+// Done is closed when the program stops
+func (p *Process) Done() <-chan struct{} {
+	return p.done
+}
+
+// Wait waits until the program stops and returns its result
+func (p *Process) Wait(ctx context.Context) (Result, error) {
+	select {
+	case <-p.done:
+		return p.result, nil
+	case <-ctx.Done():
+		return Result{}, ctx.Err()
+	}
+}
+
+// Stop terminates the program, like pressing ctrl+t. Programs that do not stop
+// on the terminate event are stopped anyway.
+func (p *Process) Stop(ctx context.Context) error {
+	res, err := p.conn.Execute(ctx, fmt.Sprintf("%v.kill(%v)", connection.RuntimeModuleName, p.PID))
+	if err != nil {
+		return connection.RpcError(err)
+	}
+
+	var killed bool
+	err = connection.Unpack(res, &killed)
+	if err != nil {
+		return err
+	}
+	if !killed {
+		select {
+		case <-p.done:
+			return nil
+		default:
+		}
+		p.cancel()
+		return fmt.Errorf("%w: %v", ErrNoSuchProcess, p.PID)
+	}
+	return nil
+}
+
+func call(ctx context.Context, conn connection.Connection, function string, args ...any) ([]any, error) {
+	arguments, err := connection.LuaArgs(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := conn.Execute(ctx, fmt.Sprintf("%v.%v(%v)", ModuleName, function, arguments))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+	return res, nil
+}
+
+// Path returns the path where programs are searched, a colon separated list of directories.
+func Path(ctx context.Context, conn connection.Connection) (path string, err error) {
+	res, err := call(ctx, conn, "path")
+	if err != nil {
+		return "", err
+	}
+	err = connection.Unpack(res, &path)
+	return path, err
+}
+
+// SetPath sets the path where programs are searched.
+func SetPath(ctx context.Context, conn connection.Connection, path string) error {
+	_, err := call(ctx, conn, "setPath", path)
+	return err
+}
+
+// Dir returns the current working directory of the shell.
+func Dir(ctx context.Context, conn connection.Connection) (dir string, err error) {
+	res, err := call(ctx, conn, "dir")
+	if err != nil {
+		return "", err
+	}
+	err = connection.Unpack(res, &dir)
+	return dir, err
+}
+
+// SetDir sets the current working directory of the shell.
+func SetDir(ctx context.Context, conn connection.Connection, dir string) error {
+	_, err := call(ctx, conn, "setDir", dir)
+	return err
+}
+
+// ResolveProgram returns the absolute path of a program, or an empty string if it cannot be found.
+func ResolveProgram(ctx context.Context, conn connection.Connection, program string) (path string, err error) {
+	res, err := call(ctx, conn, "resolveProgram", program)
+	if err != nil {
+		return "", err
+	}
+	err = connection.Unpack(res, &path)
+	return path, err
+}
+
+// Aliases returns all aliases of the shell, mapped to their programs.
+func Aliases(ctx context.Context, conn connection.Connection) (aliases map[string]string, err error) {
+	res, err := call(ctx, conn, "aliases")
+	if err != nil {
+		return nil, err
+	}
+	err = connection.Unpack(res, &aliases)
+	return aliases, err
+}
+
+// SetAlias creates an alias for a program.
+func SetAlias(ctx context.Context, conn connection.Connection, alias, program string) error {
+	_, err := call(ctx, conn, "setAlias", alias, program)
+	return err
+}
+
+// ClearAlias removes an alias.
+func ClearAlias(ctx context.Context, conn connection.Connection, alias string) error {
+	_, err := call(ctx, conn, "clearAlias", alias)
+	return err
+}
+
+// Programs returns the names of all programs on the path, sorted by name.
+func Programs(ctx context.Context, conn connection.Connection, includeHidden bool) (programs []string, err error) {
+	res, err := call(ctx, conn, "programs", includeHidden)
+	if err != nil {
+		return nil, err
+	}
+	err = connection.Unpack(res, &programs)
+	sort.Strings(programs)
+	return programs, err
+}
+
+// launchFunc opens a new multishell tab running a program and returns its id. The arguments are passed
+// to the program unchanged, unlike with shell.openTab, which splits them at spaces.
+const launchFunc = `function(program, ...) if not multishell then return nil end local path = shell.resolveProgram(program) if not path then return false end return multishell.launch({ shell = shell, multishell = multishell }, path, ...) end`
+
+// Launch runs a program with the given arguments in a new multishell tab and returns the id of the tab.
+// Multishell is only available on advanced computers.
+//
+// Multishell does not allow stopping a tab, it only closes when its program exits. Use Start
+// for programs that have to be stopped (Process.Stop) or whose exit has to be observed.
+func Launch(ctx context.Context, conn connection.Connection, program string, args ...string) (int, error) {
+	arguments := make([]any, 0, len(args)+1)
+	arguments = append(arguments, program)
+	for _, arg := range args {
+		arguments = append(arguments, arg)
+	}
+	lua, err := connection.LuaArgs(arguments...)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := conn.Execute(ctx, fmt.Sprintf("(%v)(%v)", launchFunc, lua))
+	if err != nil {
+		return 0, connection.RpcError(err)
+	}
+	if len(res) < 1 || res[0] == nil {
+		return 0, ErrMultishellNotAvailable
+	}
+	if res[0] == false {
+		return 0, fmt.Errorf("%w: %v", ErrNoSuchProgram, program)
+	}
+
+	var tab int
+	err = connection.Unpack(res, &tab)
+	return tab, err
+}
+
+// SetFocus switches to a multishell tab. It returns false if there is no such tab.
+func SetFocus(ctx context.Context, conn connection.Connection, tab int) (bool, error) {
+	return connection.DoActionBool(ctx, conn, fmt.Sprintf("%v.setFocus(%v)", MultishellModuleName, tab))
+}
+
+// SetTitle sets the title of a multishell tab.
+func SetTitle(ctx context.Context, conn connection.Connection, tab int, title string) error {
+	_, err := conn.Execute(ctx, fmt.Sprintf("%v.setTitle(%v, %v)", MultishellModuleName, tab, connection.LuaString(title)))
+	if err != nil {
+		return connection.RpcError(err)
+	}
+	return nil
+}
+
+// TabCount returns the number of multishell tabs.
+func TabCount(ctx context.Context, conn connection.Connection) (count int, err error) {
+	res, err := conn.Execute(ctx, fmt.Sprintf("%v.getCount()", MultishellModuleName))
+	if err != nil {
+		return 0, connection.RpcError(err)
+	}
+	err = connection.Unpack(res, &count)
+	return count, err
+}
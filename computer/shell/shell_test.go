@@ -0,0 +1,84 @@
+package shell
+
+import (
+	"context"
+	"errors"
+	"github.com/m4schini/computercraft-go/test"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRun(t *testing.T) {
+	//arrange
+	var expected = `ccgo.run("mine", "16", "north side")`
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{float64(4)})
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	//act
+	go func() {
+		// wait until the program was started
+		for len(conn.Executed()) == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		conn.Emit(ExitEvent, float64(3), false, "other program")
+		conn.Emit(ExitEvent, float64(4), true, "Mined 16 blocks\n")
+	}()
+	result, err := Run(ctx, conn, "mine", "16", "north side")
+
+	//assert
+	t.Logf("expected: %v", expected)
+	t.Logf("  actual: %v %+v", conn.Executed()[0], result)
+	if err != nil || conn.Executed()[0] != expected {
+		t.FailNow()
+	}
+	if !result.Success || result.Output != "Mined 16 blocks\n" {
+		t.FailNow()
+	}
+}
+
+func TestLaunch_noMultishell(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+
+	//act
+	_, err := Launch(context.TODO(), conn, "worm")
+
+	//assert
+	if err != ErrMultishellNotAvailable {
+		t.FailNow()
+	}
+}
+
+func TestLaunch_arguments(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{float64(2)})
+
+	//act
+	tab, err := Launch(context.TODO(), conn, "echo", "hello world", `say "hi"`)
+
+	//assert
+	commands := conn.Executed()
+	t.Logf("actual: %v %v %v", tab, err, commands)
+	if err != nil || tab != 2 || !strings.HasSuffix(commands[0], `("echo", "hello world", "say \"hi\"")`) {
+		t.FailNow()
+	}
+}
+
+func TestLaunch_noSuchProgram(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{false})
+
+	//act
+	_, err := Launch(context.TODO(), conn, "missing")
+
+	//assert
+	t.Logf("actual: %v", err)
+	if !errors.Is(err, ErrNoSuchProgram) {
+		t.FailNow()
+	}
+}
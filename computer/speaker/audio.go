@@ -0,0 +1,179 @@
+package speaker
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+)
+
+var ErrUnsupportedWAV = errors.New("unsupported wav file")
+
+// Source is a stream of 16-bit PCM samples (48kHz, mono)
+type Source interface {
+	// ReadSamples reads up to len(buf) samples into buf. It returns io.EOF at the end of the stream.
+	ReadSamples(buf []int16) (int, error)
+}
+
+type samples struct {
+	pcm []int16
+}
+
+// Samples returns a source playing the given samples (48kHz, mono)
+func Samples(pcm []int16) Source {
+	return &samples{pcm: pcm}
+}
+
+func (s *samples) ReadSamples(buf []int16) (int, error) {
+	if len(s.pcm) == 0 {
+		return 0, io.EOF
+	}
+	n := copy(buf, s.pcm)
+	s.pcm = s.pcm[n:]
+	return n, nil
+}
+
+// wav is a source reading the samples of a wav file. Channels are mixed down to mono
+// and the samples are resampled to 48kHz.
+type wav struct {
+	r             *bufio.Reader
+	remaining     int64
+	channels      int
+	bitsPerSample int
+	sampleRate    int
+
+	// position is the position of the next output sample in input samples
+	position float64
+	// frames are the input samples at floor(position) and after it
+	frames [2]int16
+	// read is the number of input samples read so far
+	read int64
+	eof  bool
+}
+
+// ReadWAV returns a source reading the samples of a wav file with 8 or 16-bit PCM samples.
+func ReadWAV(r io.Reader) (Source, error) {
+	br := bufio.NewReader(r)
+
+	var header [12]byte
+	_, err := io.ReadFull(br, header[:])
+	if err != nil {
+		return nil, err
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return nil, fmt.Errorf("%w: not a wave file", ErrUnsupportedWAV)
+	}
+
+	w := &wav{r: br}
+	hasFormat := false
+	for {
+		var chunk [8]byte
+		_, err = io.ReadFull(br, chunk[:])
+		if err != nil {
+			return nil, fmt.Errorf("%w: no data chunk", ErrUnsupportedWAV)
+		}
+		id := string(chunk[0:4])
+		size := int64(binary.LittleEndian.Uint32(chunk[4:8]))
+
+		switch id {
+		case "fmt ":
+			if size < 16 || size > 64 {
+				return nil, fmt.Errorf("%w: invalid format chunk", ErrUnsupportedWAV)
+			}
+			format := make([]byte, size+size%2)
+			_, err = io.ReadFull(br, format)
+			if err != nil {
+				return nil, err
+			}
+			if binary.LittleEndian.Uint16(format[0:2]) != 1 {
+				return nil, fmt.Errorf("%w: only pcm is supported", ErrUnsupportedWAV)
+			}
+			w.channels = int(binary.LittleEndian.Uint16(format[2:4]))
+			w.sampleRate = int(binary.LittleEndian.Uint32(format[4:8]))
+			w.bitsPerSample = int(binary.LittleEndian.Uint16(format[14:16]))
+			if w.channels < 1 || w.sampleRate < 1 || (w.bitsPerSample != 8 && w.bitsPerSample != 16) {
+				return nil, fmt.Errorf("%w: %v channels, %v Hz, %v bits", ErrUnsupportedWAV, w.channels, w.sampleRate, w.bitsPerSample)
+			}
+			hasFormat = true
+		case "data":
+			if !hasFormat {
+				return nil, fmt.Errorf("%w: data before format", ErrUnsupportedWAV)
+			}
+			w.remaining = size
+			return w, nil
+		default:
+			// chunks are padded to an even size
+			_, err = br.Discard(int(size + size%2))
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+}
+
+// frame reads the next input sample, mixed down to mono
+func (w *wav) frame() (int16, error) {
+	bytesPerSample := w.bitsPerSample / 8
+	if w.remaining < int64(bytesPerSample*w.channels) {
+		return 0, io.EOF
+	}
+
+	var sum int
+	for c := 0; c < w.channels; c++ {
+		if bytesPerSample == 1 {
+			b, err := w.r.ReadByte()
+			if err != nil {
+				return 0, err
+			}
+			// 8-bit wav samples are unsigned
+			sum += (int(b) - 128) << 8
+		} else {
+			var b [2]byte
+			_, err := io.ReadFull(w.r, b[:])
+			if err != nil {
+				return 0, err
+			}
+			sum += int(int16(binary.LittleEndian.Uint16(b[:])))
+		}
+	}
+	w.remaining -= int64(bytesPerSample * w.channels)
+	return int16(sum / w.channels), nil
+}
+
+// advance reads input samples until frames contains the samples around position
+func (w *wav) advance() error {
+	for w.read < int64(w.position)+2 {
+		sample, err := w.frame()
+		if err == io.EOF {
+			// repeat the last sample, so the last input sample is played
+			if w.read == 0 || w.eof || int64(w.position) > w.read-1 {
+				return io.EOF
+			}
+			w.eof = true
+			sample = w.frames[1]
+		} else if err != nil {
+			return err
+		}
+		w.frames[0], w.frames[1] = w.frames[1], sample
+		w.read++
+	}
+	return nil
+}
+
+func (w *wav) ReadSamples(buf []int16) (int, error) {
+	step := float64(w.sampleRate) / SampleRate
+	for i := range buf {
+		err := w.advance()
+		if err != nil {
+			return i, err
+		}
+
+		// linear interpolation between the two surrounding input samples
+		t := w.position - float64(int64(w.position))
+		sample := float64(w.frames[0])*(1-t) + float64(w.frames[1])*t
+		buf[i] = int16(sample)
+		w.position += step
+	}
+	return len(buf), nil
+}
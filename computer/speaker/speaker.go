@@ -0,0 +1,196 @@
+package speaker
+
+import (
+	"context"
+	"fmt"
+	"github.com/m4schini/computercraft-go/computer/peripheral"
+	"github.com/m4schini/computercraft-go/connection"
+	"io"
+)
+
+const (
+	PeripheralType = "speaker"
+
+	// AudioEmptyEvent is queued when the audio buffer of a speaker has room for more samples
+	AudioEmptyEvent = "speaker_audio_empty"
+
+	// SampleRate is the sample rate of audio played by speakers
+	SampleRate = 48000
+	// ChunkSize is the number of samples sent to the speaker at once by Stream
+	ChunkSize = 16 * 1024
+)
+
+// Instrument is an instrument of a note block
+type Instrument string
+
+const (
+	Harp          Instrument = "harp"
+	BaseDrum      Instrument = "basedrum"
+	Snare         Instrument = "snare"
+	Hat           Instrument = "hat"
+	Bass          Instrument = "bass"
+	Flute         Instrument = "flute"
+	Bell          Instrument = "bell"
+	Guitar        Instrument = "guitar"
+	Chime         Instrument = "chime"
+	Xylophone     Instrument = "xylophone"
+	IronXylophone Instrument = "iron_xylophone"
+	CowBell       Instrument = "cow_bell"
+	Didgeridoo    Instrument = "didgeridoo"
+	Bit           Instrument = "bit"
+	Banjo         Instrument = "banjo"
+	Pling         Instrument = "pling"
+)
+
+// Speaker is a wrapped speaker.
+type Speaker struct {
+	h *peripheral.Handle
+}
+
+// New wraps a peripheral handle as a speaker.
+func New(h *peripheral.Handle) (*Speaker, error) {
+	if !h.HasType(PeripheralType) {
+		return nil, fmt.Errorf("peripheral %v is not a %v", h.Name(), PeripheralType)
+	}
+	return &Speaker{h: h}, nil
+}
+
+// Wrap returns the speaker with the given name (or on the given side).
+func Wrap(ctx context.Context, conn connection.Connection, name string) (*Speaker, error) {
+	h, err := peripheral.Wrap(ctx, conn, name)
+	if err != nil {
+		return nil, err
+	}
+	return New(h)
+}
+
+// Find returns all attached speakers.
+func Find(ctx context.Context, conn connection.Connection) ([]*Speaker, error) {
+	handles, err := peripheral.Find(ctx, conn, PeripheralType)
+	if err != nil {
+		return nil, err
+	}
+
+	speakers := make([]*Speaker, len(handles))
+	for i, h := range handles {
+		speakers[i] = &Speaker{h: h}
+	}
+	return speakers, nil
+}
+
+// Name returns the peripheral name of the speaker
+func (s *Speaker) Name() string {
+	return s.h.Name()
+}
+
+// PlayNote plays a note block note. The volume ranges from 0 to 3, the pitch from 0 to 24 semitones.
+// It returns false if too many notes were played in this tick.
+func (s *Speaker) PlayNote(ctx context.Context, instrument Instrument, volume float64, pitch int) (played bool, err error) {
+	res, err := s.h.Call(ctx, "playNote", string(instrument), volume, pitch)
+	if err != nil {
+		return false, err
+	}
+	err = connection.Unpack(res, &played)
+	return played, err
+}
+
+// PlaySound plays a minecraft sound, e.g. "entity.creeper.primed". The volume ranges from 0 to 3,
+// the speed from 0.5 to 2. It returns false if another sound was started in this tick, or audio is playing.
+func (s *Speaker) PlaySound(ctx context.Context, name string, volume, speed float64) (played bool, err error) {
+	res, err := s.h.Call(ctx, "playSound", name, volume, speed)
+	if err != nil {
+		return false, err
+	}
+	err = connection.Unpack(res, &played)
+	return played, err
+}
+
+// PlayAudio queues signed 8-bit PCM samples (48kHz, mono) to be played. The volume ranges from 0 to 3.
+// It returns false if the buffer of the speaker is full, the samples are not queued then.
+func (s *Speaker) PlayAudio(ctx context.Context, samples []int8, volume float64) (queued bool, err error) {
+	res, err := s.h.Call(ctx, "playAudio", samples, volume)
+	if err != nil {
+		return false, err
+	}
+	err = connection.Unpack(res, &queued)
+	return queued, err
+}
+
+// Stop stops all audio of the speaker.
+func (s *Speaker) Stop(ctx context.Context) error {
+	_, err := s.h.Call(ctx, "stop")
+	return err
+}
+
+// Stream plays all samples of src. Samples are sent in chunks, whenever the speaker
+// has room for more samples. Stream returns when all samples were queued.
+//
+// The speaker plays signed 8-bit samples, so the 16-bit samples are converted by keeping
+// their high byte, without dithering. DFPWM (e.g. to save audio on the computer) can be
+// encoded from the same 8-bit samples with the audio/dfpwm package.
+func (s *Speaker) Stream(ctx context.Context, src Source, volume float64) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	events, err := s.h.Conn().Subscribe(ctx, AudioEmptyEvent)
+	if err != nil {
+		return err
+	}
+
+	buf := make([]int16, ChunkSize)
+	chunk := make([]int8, ChunkSize)
+	for {
+		n, readErr := src.ReadSamples(buf)
+		if readErr != nil && readErr != io.EOF {
+			return readErr
+		}
+
+		if n > 0 {
+			for i, sample := range buf[:n] {
+				chunk[i] = int8(sample >> 8)
+			}
+			err = s.play(ctx, events, chunk[:n], volume)
+			if err != nil {
+				return err
+			}
+		}
+		if readErr == io.EOF {
+			return nil
+		}
+	}
+}
+
+// play queues a chunk, waiting for the speaker to have room for it
+func (s *Speaker) play(ctx context.Context, events <-chan connection.Event, chunk []int8, volume float64) error {
+	for {
+		queued, err := s.PlayAudio(ctx, chunk, volume)
+		if err != nil || queued {
+			return err
+		}
+
+		if err = s.awaitEmpty(ctx, events); err != nil {
+			return err
+		}
+	}
+}
+
+// awaitEmpty waits until the buffer of this speaker has room for more samples
+func (s *Speaker) awaitEmpty(ctx context.Context, events <-chan connection.Event) error {
+	for {
+		select {
+		case event, ok := <-events:
+			if !ok {
+				if ctx.Err() != nil {
+					return ctx.Err()
+				}
+				return connection.ClosedChannelErr
+			}
+			var name string
+			if event.Arg(0, &name) == nil && name == s.Name() {
+				return nil
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
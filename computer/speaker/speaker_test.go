@@ -0,0 +1,122 @@
+package speaker
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"github.com/m4schini/computercraft-go/test"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+var speaker = test.Peripheral("speaker_0", []string{"speaker"}, []string{"playNote", "playSound", "playAudio", "stop"})
+
+// wavFile returns a wav file with 16-bit samples
+func wavFile(sampleRate, channels int, samples ...int16) []byte {
+	var buf bytes.Buffer
+	data := len(samples) * 2
+	buf.WriteString("RIFF")
+	binary.Write(&buf, binary.LittleEndian, uint32(36+data))
+	buf.WriteString("WAVEfmt ")
+	for _, v := range []any{
+		uint32(16), uint16(1), uint16(channels), uint32(sampleRate),
+		uint32(sampleRate * channels * 2), uint16(channels * 2), uint16(16),
+	} {
+		binary.Write(&buf, binary.LittleEndian, v)
+	}
+	buf.WriteString("data")
+	binary.Write(&buf, binary.LittleEndian, uint32(data))
+	binary.Write(&buf, binary.LittleEndian, samples)
+	return buf.Bytes()
+}
+
+func readAll(src Source) ([]int16, error) {
+	var all []int16
+	buf := make([]int16, 3)
+	for {
+		n, err := src.ReadSamples(buf)
+		all = append(all, buf[:n]...)
+		if err == io.EOF {
+			return all, nil
+		}
+		if err != nil {
+			return all, err
+		}
+	}
+}
+
+func TestReadWAV_stereo(t *testing.T) {
+	//arrange
+	var expected = []int16{150, -100, 0, 1000}
+	file := wavFile(SampleRate, 2, 100, 200, -100, -100, 0, 0, 2000, 0)
+
+	//act
+	src, err := ReadWAV(bytes.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := readAll(src)
+
+	//assert
+	t.Logf("expected: %v", expected)
+	t.Logf("  actual: %v", actual)
+	if err != nil || !reflect.DeepEqual(expected, actual) {
+		t.FailNow()
+	}
+}
+
+func TestReadWAV_resample(t *testing.T) {
+	//arrange
+	var expected = []int16{0, 500, 1000, 1500, 2000, 2000}
+	file := wavFile(SampleRate/2, 1, 0, 1000, 2000)
+
+	//act
+	src, err := ReadWAV(bytes.NewReader(file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	actual, err := readAll(src)
+
+	//assert
+	t.Logf("expected: %v", expected)
+	t.Logf("  actual: %v", actual)
+	if err != nil || !reflect.DeepEqual(expected, actual) {
+		t.FailNow()
+	}
+}
+
+func TestSpeaker_Stream(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{speaker}, []any{false}, []any{true})
+	s, err := Wrap(context.TODO(), conn, "speaker_0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+
+	//act
+	go func() {
+		// the speaker is full until the buffer was played
+		for len(conn.Executed()) < 2 {
+			time.Sleep(time.Millisecond)
+		}
+		conn.Emit(AudioEmptyEvent, "speaker_1")
+		conn.Emit(AudioEmptyEvent, "speaker_0")
+	}()
+	err = s.Stream(ctx, Samples([]int16{-32768, 256, 32767}), 1)
+
+	//assert
+	commands := conn.Executed()
+	t.Logf("actual: %v", commands[1:])
+	if err != nil || len(commands) != 3 || commands[1] != commands[2] {
+		t.FailNow()
+	}
+	if !strings.HasSuffix(commands[2], `"playAudio", {-128, 1, 127}, 1)`) {
+		t.FailNow()
+	}
+}
@@ -0,0 +1,173 @@
+package term
+
+import (
+	"context"
+	"fmt"
+	"github.com/m4schini/computercraft-go/computer/colors"
+	"github.com/m4schini/computercraft-go/connection"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// MirrorEvent is the event the lua runtime sends terminal changes with
+	MirrorEvent = "ccgo_term"
+
+	stopMirrorTimeout = 5 * time.Second
+)
+
+// Line is a single line of a terminal, in the format of term.blit.
+type Line struct {
+	Text       string `lua:"text"`
+	Foreground string `lua:"fg"`
+	Background string `lua:"bg"`
+}
+
+// RGB is a colour of the palette with channels between 0 and 1.
+type RGB struct {
+	R, G, B float64
+}
+
+// Frame is the content of a mirrored terminal.
+type Frame struct {
+	Width, Height    int
+	CursorX, CursorY int
+	CursorBlink      bool
+	Lines            []Line
+	Palette          map[colors.Color]RGB
+}
+
+// Text returns the text of all lines, separated by line breaks.
+func (f Frame) Text() string {
+	lines := make([]string, len(f.Lines))
+	for i, line := range f.Lines {
+		lines[i] = line.Text
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (f Frame) clone() Frame {
+	c := f
+	c.Lines = append([]Line{}, f.Lines...)
+	c.Palette = make(map[colors.Color]RGB, len(f.Palette))
+	for k, v := range f.Palette {
+		c.Palette[k] = v
+	}
+	return c
+}
+
+// update is the payload of a MirrorEvent. Only lines and palette entries that
+// changed since the last update are included.
+type update struct {
+	Width       int                        `lua:"width"`
+	Height      int                        `lua:"height"`
+	CursorX     int                        `lua:"cursorX"`
+	CursorY     int                        `lua:"cursorY"`
+	CursorBlink bool                       `lua:"cursorBlink"`
+	Lines       map[int]Line               `lua:"lines"`
+	Palette     map[colors.Color][]float64 `lua:"palette"`
+}
+
+func (f *Frame) apply(u update) {
+	f.Width, f.Height = u.Width, u.Height
+	f.CursorX, f.CursorY, f.CursorBlink = u.CursorX, u.CursorY, u.CursorBlink
+	if len(f.Lines) != u.Height {
+		lines := make([]Line, u.Height)
+		copy(lines, f.Lines)
+		f.Lines = lines
+	}
+	for y, line := range u.Lines {
+		if y >= 1 && y <= len(f.Lines) {
+			f.Lines[y-1] = line
+		}
+	}
+	if f.Palette == nil {
+		f.Palette = make(map[colors.Color]RGB)
+	}
+	for colour, rgb := range u.Palette {
+		if len(rgb) == 3 {
+			f.Palette[colour] = RGB{R: rgb[0], G: rgb[1], B: rgb[2]}
+		}
+	}
+}
+
+// Mirror is a live copy of the terminal of a computer. While mirroring, the lua
+// runtime redirects the terminal into a window and streams every change of it.
+type Mirror struct {
+	mu      sync.Mutex
+	frame   Frame
+	updates chan Frame
+	done    chan struct{}
+}
+
+// StartMirror starts mirroring the terminal of the computer until ctx is done.
+func StartMirror(ctx context.Context, conn connection.Connection) (*Mirror, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	events, err := conn.Subscribe(ctx, MirrorEvent)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+
+	_, err = conn.Execute(ctx, fmt.Sprintf("%v.mirror(true)", connection.RuntimeModuleName))
+	if err != nil {
+		cancel()
+		return nil, connection.RpcError(err)
+	}
+
+	m := &Mirror{
+		updates: make(chan Frame, 1),
+		done:    make(chan struct{}),
+	}
+	go m.run(conn, events, cancel)
+	return m, nil
+}
+
+func (m *Mirror) run(conn connection.Connection, events <-chan connection.Event, cancel context.CancelFunc) {
+	defer close(m.done)
+	defer cancel()
+	defer close(m.updates)
+
+	for event := range events {
+		var u update
+		err := event.Arg(0, &u)
+		if err != nil {
+			continue
+		}
+
+		m.mu.Lock()
+		m.frame.apply(u)
+		frame := m.frame.clone()
+		m.mu.Unlock()
+
+		// only the latest frame is kept for slow readers
+		select {
+		case <-m.updates:
+		default:
+		}
+		m.updates <- frame
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), stopMirrorTimeout)
+	defer cancel()
+	_, _ = conn.Execute(ctx, fmt.Sprintf("%v.mirror(false)", connection.RuntimeModuleName))
+}
+
+// Frame returns the current content of the terminal.
+func (m *Mirror) Frame() Frame {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.frame.clone()
+}
+
+// Updates returns a channel receiving the content of the terminal after every
+// change. Frames are skipped if they are not received fast enough.
+func (m *Mirror) Updates() <-chan Frame {
+	return m.updates
+}
+
+// Done is closed after mirroring stopped.
+func (m *Mirror) Done() <-chan struct{} {
+	return m.done
+}
@@ -0,0 +1,51 @@
+package term
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/computer/colors"
+	"github.com/m4schini/computercraft-go/test"
+	"testing"
+)
+
+func TestMirror(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	m, err := StartMirror(ctx, conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	//act
+	conn.Emit(MirrorEvent, map[string]any{
+		"width": float64(3), "height": float64(2),
+		"cursorX": float64(2), "cursorY": float64(1), "cursorBlink": true,
+		"lines": []any{
+			map[string]any{"text": "abc", "fg": "000", "bg": "fff"},
+			map[string]any{"text": "   ", "fg": "000", "bg": "fff"},
+		},
+		"palette": map[string]any{"16384": []any{0.8, 0.3, 0.3}},
+	})
+	first := <-m.Updates()
+	conn.Emit(MirrorEvent, map[string]any{
+		"width": float64(3), "height": float64(2),
+		"cursorX": float64(3), "cursorY": float64(2),
+		"lines":   map[string]any{"2": map[string]any{"text": "de ", "fg": "000", "bg": "fff"}},
+		"palette": []any{},
+	})
+	second := <-m.Updates()
+
+	//assert
+	t.Logf("first:\n%v", first.Text())
+	t.Logf("second:\n%v", second.Text())
+	if first.Text() != "abc\n   " || first.Palette[colors.Red].R != 0.8 {
+		t.FailNow()
+	}
+	if second.Text() != "abc\nde " || second.CursorY != 2 || second.Palette[colors.Red].G != 0.3 {
+		t.FailNow()
+	}
+	if conn.Executed()[0] != "ccgo.mirror(true)" {
+		t.FailNow()
+	}
+}
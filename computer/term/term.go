@@ -0,0 +1,193 @@
+package term
+
+import (
+	"context"
+	"fmt"
+	"github.com/m4schini/computercraft-go/computer/colors"
+	"github.com/m4schini/computercraft-go/connection"
+)
+
+const (
+	ModuleName = "term"
+)
+
+// Terminal is the term api of a computer. Monitors (see monitor.Monitor) implement it as well.
+type Terminal interface {
+	// Write writes text at the current cursor position, moving the cursor to the end of the text.
+	Write(ctx context.Context, text string) error
+	// Blit writes text at the current cursor position. fg and bg contain a blit color
+	// character for each character of text.
+	Blit(ctx context.Context, text, fg, bg string) error
+	// Clear clears the terminal, filling it with the current background colour.
+	Clear(ctx context.Context) error
+	// ClearLine clears the line the cursor is currently on.
+	ClearLine(ctx context.Context) error
+	// Scroll moves all positions up (or down) by n lines.
+	Scroll(ctx context.Context, n int) error
+
+	// CursorPos gets the position of the cursor.
+	CursorPos(ctx context.Context) (int, int, error)
+	// SetCursorPos sets the position of the cursor. The top left corner is 1, 1.
+	SetCursorPos(ctx context.Context, x, y int) error
+	// CursorBlink checks if the cursor is currently blinking.
+	CursorBlink(ctx context.Context) (bool, error)
+	// SetCursorBlink sets whether the cursor should be visible (and blinking).
+	SetCursorBlink(ctx context.Context, blink bool) error
+
+	// Size gets the size of the terminal in characters.
+	Size(ctx context.Context) (int, int, error)
+	// IsColour checks if the terminal supports colours.
+	IsColour(ctx context.Context) (bool, error)
+
+	// TextColour gets the colour new text is written in.
+	TextColour(ctx context.Context) (colors.Color, error)
+	// SetTextColour sets the colour new text is written in.
+	SetTextColour(ctx context.Context, colour colors.Color) error
+	// BackgroundColour gets the background colour new text is written with.
+	BackgroundColour(ctx context.Context) (colors.Color, error)
+	// SetBackgroundColour sets the background colour new text is written with.
+	SetBackgroundColour(ctx context.Context, colour colors.Color) error
+
+	// PaletteColour gets the red, green and blue channels (0 to 1) a colour is displayed as.
+	PaletteColour(ctx context.Context, colour colors.Color) (float64, float64, float64, error)
+	// SetPaletteColour changes the red, green and blue channels (0 to 1) a colour is displayed as.
+	SetPaletteColour(ctx context.Context, colour colors.Color, r, g, b float64) error
+}
+
+type terminal struct {
+	conn connection.Connection
+}
+
+// New returns the terminal of the computer
+func New(conn connection.Connection) Terminal {
+	return &terminal{conn: conn}
+}
+
+func (t *terminal) call(ctx context.Context, method string, args ...any) ([]any, error) {
+	arguments, err := connection.LuaArgs(args...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.conn.Execute(ctx, fmt.Sprintf("%v.%v(%v)", ModuleName, method, arguments))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+	return res, nil
+}
+
+func (t *terminal) Write(ctx context.Context, text string) error {
+	_, err := t.call(ctx, "write", text)
+	return err
+}
+
+func (t *terminal) Blit(ctx context.Context, text, fg, bg string) error {
+	if len(text) != len(fg) || len(text) != len(bg) {
+		return fmt.Errorf("blit arguments must be the same length (%v, %v, %v)", len(text), len(fg), len(bg))
+	}
+	_, err := t.call(ctx, "blit", text, fg, bg)
+	return err
+}
+
+func (t *terminal) Clear(ctx context.Context) error {
+	_, err := t.call(ctx, "clear")
+	return err
+}
+
+func (t *terminal) ClearLine(ctx context.Context) error {
+	_, err := t.call(ctx, "clearLine")
+	return err
+}
+
+func (t *terminal) Scroll(ctx context.Context, n int) error {
+	_, err := t.call(ctx, "scroll", n)
+	return err
+}
+
+func (t *terminal) CursorPos(ctx context.Context) (x, y int, err error) {
+	res, err := t.call(ctx, "getCursorPos")
+	if err != nil {
+		return 0, 0, err
+	}
+	err = connection.Unpack(res, &x, &y)
+	return x, y, err
+}
+
+func (t *terminal) SetCursorPos(ctx context.Context, x, y int) error {
+	_, err := t.call(ctx, "setCursorPos", x, y)
+	return err
+}
+
+func (t *terminal) CursorBlink(ctx context.Context) (blink bool, err error) {
+	res, err := t.call(ctx, "getCursorBlink")
+	if err != nil {
+		return false, err
+	}
+	err = connection.Unpack(res, &blink)
+	return blink, err
+}
+
+func (t *terminal) SetCursorBlink(ctx context.Context, blink bool) error {
+	_, err := t.call(ctx, "setCursorBlink", blink)
+	return err
+}
+
+func (t *terminal) Size(ctx context.Context) (width, height int, err error) {
+	res, err := t.call(ctx, "getSize")
+	if err != nil {
+		return 0, 0, err
+	}
+	err = connection.Unpack(res, &width, &height)
+	return width, height, err
+}
+
+func (t *terminal) IsColour(ctx context.Context) (colour bool, err error) {
+	res, err := t.call(ctx, "isColour")
+	if err != nil {
+		return false, err
+	}
+	err = connection.Unpack(res, &colour)
+	return colour, err
+}
+
+func (t *terminal) TextColour(ctx context.Context) (colour colors.Color, err error) {
+	res, err := t.call(ctx, "getTextColour")
+	if err != nil {
+		return 0, err
+	}
+	err = connection.Unpack(res, &colour)
+	return colour, err
+}
+
+func (t *terminal) SetTextColour(ctx context.Context, colour colors.Color) error {
+	_, err := t.call(ctx, "setTextColour", colour)
+	return err
+}
+
+func (t *terminal) BackgroundColour(ctx context.Context) (colour colors.Color, err error) {
+	res, err := t.call(ctx, "getBackgroundColour")
+	if err != nil {
+		return 0, err
+	}
+	err = connection.Unpack(res, &colour)
+	return colour, err
+}
+
+func (t *terminal) SetBackgroundColour(ctx context.Context, colour colors.Color) error {
+	_, err := t.call(ctx, "setBackgroundColour", colour)
+	return err
+}
+
+func (t *terminal) PaletteColour(ctx context.Context, colour colors.Color) (r, g, b float64, err error) {
+	res, err := t.call(ctx, "getPaletteColour", colour)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	err = connection.Unpack(res, &r, &g, &b)
+	return r, g, b, err
+}
+
+func (t *terminal) SetPaletteColour(ctx context.Context, colour colors.Color, r, g, b float64) error {
+	_, err := t.call(ctx, "setPaletteColour", colour, r, g, b)
+	return err
+}
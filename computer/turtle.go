@@ -5,17 +5,22 @@ import (
 	"errors"
 	"fmt"
 	"github.com/m4schini/computercraft-go/computer/gps"
+	"github.com/m4schini/computercraft-go/computer/settings"
 	"github.com/m4schini/computercraft-go/connection"
 	"time"
 )
 
 type turtle struct {
+	*settings.Settings
 	conn connection.Connection
 }
 
+var _ Turtle = (*turtle)(nil)
+
 func NewTurtle(conn connection.Connection) *turtle {
 	t := new(turtle)
 	t.conn = conn
+	t.Settings = settings.New(conn)
 	return t
 }
 
@@ -55,44 +60,74 @@ func (t *turtle) Uptime(ctx context.Context) (uptime time.Duration, err error) {
 	return Uptime(ctx, t.conn)
 }
 
-func (t *turtle) Time(ctx context.Context) (time float64, err error) {
+func (t *turtle) Time(ctx context.Context) (time GameTime, err error) {
 	return Time(ctx, t.conn)
 }
 
+// UnlimitedFuel is returned by FuelLevel and FuelLimit, if turtles do not need fuel
+const UnlimitedFuel = -1
+
+// optional returns the first value as lua argument, or no argument
+func optional[T any](values []T) []any {
+	if len(values) == 0 {
+		return nil
+	}
+	return []any{values[0]}
+}
+
+// action calls a function of the turtle API, that returns whether it succeeded and a reason
+// if it failed. The reason is returned as *connection.ActionError, see ParseReason.
+func (t *turtle) action(ctx context.Context, function string, args ...any) (bool, error) {
+	arguments, err := connection.LuaArgs(args...)
+	if err != nil {
+		return false, err
+	}
+	return connection.DoAction(ctx, t.conn, fmt.Sprintf("turtle.%v(%v)", function, arguments), ParseReason)
+}
+
+// fuel calls a function of the turtle API, that returns a fuel amount or "unlimited"
+func (t *turtle) fuel(ctx context.Context, function string) (int, error) {
+	res, err := t.conn.Execute(ctx, fmt.Sprintf("turtle.%v()", function))
+	if err != nil {
+		return 0, connection.RpcError(err)
+	}
+	if len(res) > 0 && res[0] == "unlimited" {
+		return UnlimitedFuel, nil
+	}
+
+	var fuel int
+	err = connection.Unpack(res, &fuel)
+	return fuel, err
+}
+
 func (t *turtle) Forward(ctx context.Context) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.forward()")
+	return t.action(ctx, "forward")
 }
 
 func (t *turtle) Back(ctx context.Context) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.back()")
+	return t.action(ctx, "back")
 }
 
 func (t *turtle) Up(ctx context.Context) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.up()")
+	return t.action(ctx, "up")
 }
 
 func (t *turtle) Down(ctx context.Context) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.down()")
+	return t.action(ctx, "down")
 }
 
 func (t *turtle) TurnLeft(ctx context.Context) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.turnLeft()")
+	return t.action(ctx, "turnLeft")
 }
 
 func (t *turtle) TurnRight(ctx context.Context) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.turnRight()")
+	return t.action(ctx, "turnRight")
 }
 
 func (t *turtle) _detect(ctx context.Context, command string) bool {
 	conn := t.conn
 	res, err := conn.Execute(ctx, command)
-	if err != nil {
+	if err != nil || len(res) < 1 {
 		return false
 	}
 
@@ -114,57 +149,44 @@ func (t *turtle) DetectDown(ctx context.Context) (detected bool) {
 
 }
 
-func (t *turtle) Dig(ctx context.Context) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.dig()")
+func (t *turtle) Dig(ctx context.Context, side ...Side) (success bool, err error) {
+	return t.action(ctx, "dig", optional(side)...)
 }
 
-func (t *turtle) DigDown(ctx context.Context) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.digDown()")
+func (t *turtle) DigDown(ctx context.Context, side ...Side) (success bool, err error) {
+	return t.action(ctx, "digDown", optional(side)...)
 }
 
-func (t *turtle) DigUp(ctx context.Context) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.digUp()")
+func (t *turtle) DigUp(ctx context.Context, side ...Side) (success bool, err error) {
+	return t.action(ctx, "digUp", optional(side)...)
 }
 
-func (t *turtle) Place(ctx context.Context) (placed bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.place()")
+func (t *turtle) Place(ctx context.Context, text ...string) (placed bool, err error) {
+	return t.action(ctx, "place", optional(text)...)
 }
 
-func (t *turtle) PlaceUp(ctx context.Context) (placed bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.placeUp()")
-
+func (t *turtle) PlaceUp(ctx context.Context, text ...string) (placed bool, err error) {
+	return t.action(ctx, "placeUp", optional(text)...)
 }
 
-func (t *turtle) PlaceDown(ctx context.Context) (placed bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.placeDown()")
-
+func (t *turtle) PlaceDown(ctx context.Context, text ...string) (placed bool, err error) {
+	return t.action(ctx, "placeDown", optional(text)...)
 }
 
-func (t *turtle) Drop(ctx context.Context, count int) (dropped bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, fmt.Sprintf("turtle.drop(%v)", count))
-
+func (t *turtle) Drop(ctx context.Context, count ...int) (dropped bool, err error) {
+	return t.action(ctx, "drop", optional(count)...)
 }
 
-func (t *turtle) DropUp(ctx context.Context, count int) (dropped bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, fmt.Sprintf("turtle.dropUp(%v)", count))
+func (t *turtle) DropUp(ctx context.Context, count ...int) (dropped bool, err error) {
+	return t.action(ctx, "dropUp", optional(count)...)
 }
 
-func (t *turtle) DropDown(ctx context.Context, count int) (dropped bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, fmt.Sprintf("turtle.dropDown(%v)", count))
+func (t *turtle) DropDown(ctx context.Context, count ...int) (dropped bool, err error) {
+	return t.action(ctx, "dropDown", optional(count)...)
 }
 
 func (t *turtle) Select(ctx context.Context, slot int) (selected bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, fmt.Sprintf("turtle.select(%v)", slot))
+	return t.action(ctx, "select", slot)
 }
 
 func (t *turtle) SelectedSlot(ctx context.Context) (slot int, err error) {
@@ -172,14 +194,20 @@ func (t *turtle) SelectedSlot(ctx context.Context) (slot int, err error) {
 	return connection.DoActionInt(ctx, conn, "turtle.getSelectedSlot()")
 }
 
-func (t *turtle) ItemCount(ctx context.Context, slot int) (count int, err error) {
-	conn := t.conn
-	return connection.DoActionInt(ctx, conn, fmt.Sprintf("turtle.getItemCount(%v)", slot))
+func (t *turtle) ItemCount(ctx context.Context, slot ...int) (count int, err error) {
+	arguments, err := connection.LuaArgs(optional(slot)...)
+	if err != nil {
+		return 0, err
+	}
+	return connection.DoActionInt(ctx, t.conn, fmt.Sprintf("turtle.getItemCount(%v)", arguments))
 }
 
-func (t *turtle) ItemSpace(ctx context.Context, slot int) (space int, err error) {
-	conn := t.conn
-	return connection.DoActionInt(ctx, conn, fmt.Sprintf("turtle.getItemSpace(%v)", slot))
+func (t *turtle) ItemSpace(ctx context.Context, slot ...int) (space int, err error) {
+	arguments, err := connection.LuaArgs(optional(slot)...)
+	if err != nil {
+		return 0, err
+	}
+	return connection.DoActionInt(ctx, t.conn, fmt.Sprintf("turtle.getItemSpace(%v)", arguments))
 }
 
 func (t *turtle) ItemDetail(ctx context.Context, slot int, detailed bool) (map[string]interface{}, error) {
@@ -192,6 +220,10 @@ func (t *turtle) ItemDetail(ctx context.Context, slot int, detailed bool) (map[s
 	if len(response) < 1 {
 		return nil, errors.New("unexpected data length")
 	}
+	if response[0] == nil {
+		// the slot is empty
+		return nil, nil
+	}
 
 	slotdata, ok := response[0].(map[string]interface{})
 	if !ok {
@@ -202,78 +234,59 @@ func (t *turtle) ItemDetail(ctx context.Context, slot int, detailed bool) (map[s
 }
 
 func (t *turtle) CompareTo(ctx context.Context, slot int) (same bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, fmt.Sprintf("turtle.compareTo(%v)", slot))
+	return t.action(ctx, "compareTo", slot)
 }
 
-func (t *turtle) TransferTo(ctx context.Context, slot, count int) (transferred bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, fmt.Sprintf("turtle.transferTo(%v,%v)", slot, count))
+func (t *turtle) TransferTo(ctx context.Context, slot int, count ...int) (transferred bool, err error) {
+	return t.action(ctx, "transferTo", append([]any{slot}, optional(count)...)...)
 }
 
 func (t *turtle) Compare(ctx context.Context) (same bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.compare()")
+	return t.action(ctx, "compare")
 }
 
 func (t *turtle) CompareUp(ctx context.Context) (same bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.compareUp()")
-
+	return t.action(ctx, "compareUp")
 }
 
 func (t *turtle) CompareDown(ctx context.Context) (same bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.compareDown()")
-
+	return t.action(ctx, "compareDown")
 }
 
-func (t *turtle) Attack(ctx context.Context) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.attack()")
-
+func (t *turtle) Attack(ctx context.Context, side ...Side) (success bool, err error) {
+	return t.action(ctx, "attack", optional(side)...)
 }
 
-func (t *turtle) AttackUp(ctx context.Context) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.attackUp()")
-
+func (t *turtle) AttackUp(ctx context.Context, side ...Side) (success bool, err error) {
+	return t.action(ctx, "attackUp", optional(side)...)
 }
 
-func (t *turtle) AttackDown(ctx context.Context) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, "turtle.attackDown()")
-
+func (t *turtle) AttackDown(ctx context.Context, side ...Side) (success bool, err error) {
+	return t.action(ctx, "attackDown", optional(side)...)
 }
 
-func (t *turtle) Suck(ctx context.Context, count int) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, fmt.Sprintf("turtle.suck(%v)", count))
+func (t *turtle) Suck(ctx context.Context, count ...int) (success bool, err error) {
+	return t.action(ctx, "suck", optional(count)...)
 }
 
-func (t *turtle) SuckUp(ctx context.Context, count int) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, fmt.Sprintf("turtle.suckUp(%v)", count))
+func (t *turtle) SuckUp(ctx context.Context, count ...int) (success bool, err error) {
+	return t.action(ctx, "suckUp", optional(count)...)
 }
 
-func (t *turtle) SuckDown(ctx context.Context, count int) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, fmt.Sprintf("turtle.suckDown(%v)", count))
+func (t *turtle) SuckDown(ctx context.Context, count ...int) (success bool, err error) {
+	return t.action(ctx, "suckDown", optional(count)...)
 }
 
 func (t *turtle) FuelLevel(ctx context.Context) (fuelLevel int, err error) {
-	conn := t.conn
-	return connection.DoActionInt(ctx, conn, "turtle.getFuelLevel()")
+	return t.fuel(ctx, "getFuelLevel")
 }
 
-func (t *turtle) Refuel(ctx context.Context, count int) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, fmt.Sprintf("turtle.refuel(%v)", count))
+func (t *turtle) Refuel(ctx context.Context, count ...int) (success bool, err error) {
+	return t.action(ctx, "refuel", optional(count)...)
 }
 
 func (t *turtle) FuelLimit(ctx context.Context) (fuelLimit int, err error) {
-	conn := t.conn
-	return connection.DoActionInt(ctx, conn, "turtle.getFuelLimit()")
+	return t.fuel(ctx, "getFuelLimit")
 }
 
 func (t *turtle) _doInspect(ctx context.Context, command string) (bool, Block, error) {
@@ -287,13 +300,12 @@ func (t *turtle) _doInspect(ctx context.Context, command string) (bool, Block, e
 		return false, nil, connection.RpcError(errors.New("not enough parameter"))
 	}
 
-	errMsg, isError := res[1].(string)
-	if isError {
-		return false, nil, connection.RpcError(errors.New(errMsg))
-	}
-
 	detectedBlock, ok := res[0].(bool)
+	if !ok {
+		return false, nil, connection.UnexpectedDatatypeErr
+	}
 	if !detectedBlock {
+		// the reason is "No block to inspect", which is not an error
 		return false, nil, nil
 	}
 
@@ -319,73 +331,44 @@ func (t *turtle) InspectDown(ctx context.Context) (detected bool, block Block, e
 
 }
 
-func (t *turtle) Craft(ctx context.Context, limit int) (success bool, err error) {
-	conn := t.conn
-	return connection.DoActionBool(ctx, conn, fmt.Sprintf("turtle.craft(%v)", limit))
+func (t *turtle) Craft(ctx context.Context, limit ...int) (success bool, err error) {
+	return t.action(ctx, "craft", optional(limit)...)
 }
 
-func (t *turtle) Locate(ctx context.Context) (x int, y int, z int, err error) {
-	return gps.Locate(ctx, t.conn)
+func (t *turtle) EquipLeft(ctx context.Context) (success bool, err error) {
+	return t.action(ctx, "equipLeft")
 }
 
-func (t *turtle) LocateWithTimeout(ctx context.Context, timeout time.Duration) (x int, y int, z int, err error) {
-	return gps.LocateWithTimeout(ctx, t.conn, timeout)
+func (t *turtle) EquipRight(ctx context.Context) (success bool, err error) {
+	return t.action(ctx, "equipRight")
 }
 
-func (t *turtle) Define(ctx context.Context, name string, option ...SettingsOption) error {
-	conn := t.conn
-	_, err := conn.Execute(ctx, fmt.Sprintf("settings.define(\"%s\")", name))
-	return err
+func (t *turtle) EquippedLeft(ctx context.Context) (TurtleUpgrade, error) {
+	upgrades, err := t.Upgrades(ctx)
+	return upgrades.Left, err
 }
 
-func (t *turtle) Undefine(ctx context.Context, name string) error {
-	conn := t.conn
-	_, err := conn.Execute(ctx, fmt.Sprintf("settings.undefine(\"%s\")", name))
-	return err
+func (t *turtle) EquippedRight(ctx context.Context) (TurtleUpgrade, error) {
+	upgrades, err := t.Upgrades(ctx)
+	return upgrades.Right, err
 }
 
-func (t *turtle) Set(ctx context.Context, name, value string) error {
-	conn := t.conn
-	_, err := conn.Execute(ctx, fmt.Sprintf("settings.set(\"%s\", \"%s\")", name, value))
-	return err
-}
+// equippedFunc returns the item names of the upgrades on the left and right side
+const equippedFunc = `function() local l, r = turtle.getEquippedLeft(), turtle.getEquippedRight() return l and l.name, r and r.name end`
 
-func (t *turtle) Unset(ctx context.Context, name string) error {
-	conn := t.conn
-	_, err := conn.Execute(ctx, fmt.Sprintf("settings.unset(\"%s\")", name))
-	return err
-}
-
-func (t *turtle) Get(ctx context.Context, name string) (string, error) {
-	conn := t.conn
-	res, err := conn.Execute(ctx, fmt.Sprintf("settings.set(\"%s\")", name))
+func (t *turtle) Upgrades(ctx context.Context) (upgrades TurtleUpgrades, err error) {
+	res, err := t.conn.Execute(ctx, fmt.Sprintf("(%v)()", equippedFunc))
 	if err != nil {
-		return "", err
+		return TurtleUpgrades{}, connection.RpcError(err)
 	}
-	str, ok := res[0].(string)
-	if !ok {
-		return "", errors.New("not a string")
-	}
-	return str, nil
-}
-
-func (t *turtle) Clear(ctx context.Context) error {
-	conn := t.conn
-	_, err := conn.Execute(ctx, fmt.Sprintf("settings.clear()"))
-	return err
+	err = connection.Unpack(res, &upgrades.Left, &upgrades.Right)
+	return upgrades, err
 }
 
-func (t *turtle) Names(ctx context.Context) ([]string, error) {
-	//TODO implement me
-	panic("implement me")
-}
-
-func (t *turtle) Load(ctx context.Context, path string) (bool, error) {
-	//TODO implement me
-	panic("implement me")
+func (t *turtle) Locate(ctx context.Context) (x int, y int, z int, err error) {
+	return gps.Locate(ctx, t.conn)
 }
 
-func (t *turtle) Save(ctx context.Context, path string) (bool, error) {
-	//TODO implement me
-	panic("implement me")
+func (t *turtle) LocateWithTimeout(ctx context.Context, timeout time.Duration) (x int, y int, z int, err error) {
+	return gps.LocateWithTimeout(ctx, t.conn, timeout)
 }
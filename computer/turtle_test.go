@@ -0,0 +1,124 @@
+package computer
+
+import (
+	"context"
+	"errors"
+	"github.com/m4schini/computercraft-go/connection"
+	"github.com/m4schini/computercraft-go/test"
+	"testing"
+)
+
+func TestTurtle_Forward_obstructed(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{false, "Movement obstructed"})
+
+	//act
+	moved, err := NewTurtle(conn).Forward(context.TODO())
+
+	//assert
+	t.Logf("actual: %v %v", moved, err)
+	if moved || !errors.Is(err, ErrMovementObstructed) {
+		t.FailNow()
+	}
+	var actionErr *connection.ActionError
+	if !errors.As(err, &actionErr) || actionErr.Reason != "Movement obstructed" || actionErr.Command != "turtle.forward()" {
+		t.FailNow()
+	}
+}
+
+func TestTurtle_Dig_reasons(t *testing.T) {
+	tests := []struct {
+		name     string
+		reason   string
+		expected error
+	}{
+		{name: "bedrock", reason: "Cannot break unbreakable block", expected: ErrUnbreakable},
+		{name: "air", reason: "Nothing to dig here", expected: ErrNothingToDig},
+		{name: "unknown", reason: "Cannot break block with this tool", expected: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//arrange
+			conn := &test.ConnectionMock{}
+			conn.Respond([]any{false, tt.reason})
+
+			//act
+			dug, err := NewTurtle(conn).Dig(context.TODO())
+
+			//assert
+			t.Logf("actual: %v %v", dug, err)
+			if dug || err == nil || err.Error() != tt.reason || errors.Unwrap(err) != tt.expected {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestTurtle_optionalArguments(t *testing.T) {
+	//arrange
+	var expected = []string{
+		`turtle.dig("left")`,
+		`turtle.digUp()`,
+		`turtle.placeDown("Home")`,
+		`turtle.drop()`,
+		`turtle.suck(4)`,
+		`turtle.transferTo(3)`,
+		`turtle.refuel(0)`,
+	}
+	conn := &test.ConnectionMock{}
+	turtle := NewTurtle(conn)
+	ctx := context.TODO()
+
+	//act
+	_, _ = turtle.Dig(ctx, SideLeft)
+	_, _ = turtle.DigUp(ctx)
+	_, _ = turtle.PlaceDown(ctx, "Home")
+	_, _ = turtle.Drop(ctx)
+	_, _ = turtle.Suck(ctx, 4)
+	_, _ = turtle.TransferTo(ctx, 3)
+	_, _ = turtle.Refuel(ctx, 0)
+
+	//assert
+	actual := conn.Executed()
+	t.Logf("expected: %v", expected)
+	t.Logf("actual  : %v", actual)
+	if len(actual) != len(expected) {
+		t.FailNow()
+	}
+	for i := range expected {
+		if expected[i] != actual[i] {
+			t.FailNow()
+		}
+	}
+}
+
+func TestTurtle_Inspect_noBlock(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{false, "No block to inspect"})
+
+	//act
+	detected, block, err := NewTurtle(conn).Inspect(context.TODO())
+
+	//assert
+	t.Logf("actual: %v %v %v", detected, block, err)
+	if detected || block != nil || err != nil {
+		t.FailNow()
+	}
+}
+
+func TestTurtle_FuelLevel_unlimited(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{"unlimited"})
+
+	//act
+	fuel, err := NewTurtle(conn).FuelLevel(context.TODO())
+
+	//assert
+	t.Logf("actual: %v %v", fuel, err)
+	if fuel != UnlimitedFuel || err != nil {
+		t.FailNow()
+	}
+}
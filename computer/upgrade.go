@@ -0,0 +1,94 @@
+package computer
+
+import "strings"
+
+// TurtleUpgrade is an upgrade equipped on a side of a turtle, identified by the name of its item
+type TurtleUpgrade string
+
+const (
+	UpgradeNone TurtleUpgrade = ""
+
+	UpgradeDiamondPickaxe TurtleUpgrade = "minecraft:diamond_pickaxe"
+	UpgradeDiamondAxe     TurtleUpgrade = "minecraft:diamond_axe"
+	UpgradeDiamondShovel  TurtleUpgrade = "minecraft:diamond_shovel"
+	UpgradeDiamondHoe     TurtleUpgrade = "minecraft:diamond_hoe"
+	UpgradeDiamondSword   TurtleUpgrade = "minecraft:diamond_sword"
+	UpgradeCraftingTable  TurtleUpgrade = "minecraft:crafting_table"
+	UpgradeWirelessModem  TurtleUpgrade = "computercraft:wireless_modem_normal"
+	UpgradeEnderModem     TurtleUpgrade = "computercraft:wireless_modem_advanced"
+	UpgradeSpeaker        TurtleUpgrade = "computercraft:speaker"
+)
+
+// IsTool checks if the upgrade is a tool (pickaxe, axe, shovel, hoe or sword) of any material
+func (u TurtleUpgrade) IsTool() bool {
+	for _, suffix := range []string{"_pickaxe", "_axe", "_shovel", "_hoe", "_sword"} {
+		if strings.HasSuffix(string(u), suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsModem checks if the upgrade is a wireless or ender modem
+func (u TurtleUpgrade) IsModem() bool {
+	return strings.Contains(string(u), "modem")
+}
+
+// IsChunkLoader checks if the upgrade is a chunk loader. Chunk loaders are added
+// by addons, they are detected by their name.
+func (u TurtleUpgrade) IsChunkLoader() bool {
+	return strings.Contains(string(u), "chunk")
+}
+
+// TurtleUpgrades are the upgrades equipped on the left and right side of a turtle
+type TurtleUpgrades struct {
+	Left  TurtleUpgrade
+	Right TurtleUpgrade
+}
+
+// Side returns the side an upgrade is equipped on. If it is equipped on both sides, left is returned.
+func (u TurtleUpgrades) Side(upgrade TurtleUpgrade) (Side, bool) {
+	switch upgrade {
+	case u.Left:
+		return SideLeft, true
+	case u.Right:
+		return SideRight, true
+	default:
+		return "", false
+	}
+}
+
+// Has checks if an upgrade is equipped on any side
+func (u TurtleUpgrades) Has(upgrade TurtleUpgrade) bool {
+	_, ok := u.Side(upgrade)
+	return ok
+}
+
+func (u TurtleUpgrades) any(f func(TurtleUpgrade) bool) bool {
+	return f(u.Left) || f(u.Right)
+}
+
+// CanCraft checks if a crafting table is equipped, which is needed by Turtle.Craft
+func (u TurtleUpgrades) CanCraft() bool {
+	return u.Has(UpgradeCraftingTable)
+}
+
+// CanDig checks if a tool is equipped, which is needed to dig blocks
+func (u TurtleUpgrades) CanDig() bool {
+	return u.any(TurtleUpgrade.IsTool)
+}
+
+// CanAttack checks if a tool is equipped, which is needed to attack entities
+func (u TurtleUpgrades) CanAttack() bool {
+	return u.any(TurtleUpgrade.IsTool)
+}
+
+// IsWireless checks if a wireless or ender modem is equipped, which is needed by rednet and gps
+func (u TurtleUpgrades) IsWireless() bool {
+	return u.any(TurtleUpgrade.IsModem)
+}
+
+// CanLoadChunks checks if a chunk loader is equipped
+func (u TurtleUpgrades) CanLoadChunks() bool {
+	return u.any(TurtleUpgrade.IsChunkLoader)
+}
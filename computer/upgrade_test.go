@@ -0,0 +1,56 @@
+package computer
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/test"
+	"testing"
+)
+
+func TestTurtleUpgrades(t *testing.T) {
+	tests := []struct {
+		name      string
+		upgrades  TurtleUpgrades
+		canCraft  bool
+		canDig    bool
+		wireless  bool
+		chunkLoad bool
+	}{
+		{name: "none", upgrades: TurtleUpgrades{}},
+		{name: "mining", upgrades: TurtleUpgrades{Left: UpgradeWirelessModem, Right: UpgradeDiamondPickaxe}, canDig: true, wireless: true},
+		{name: "crafty", upgrades: TurtleUpgrades{Left: UpgradeCraftingTable, Right: "minecraft:netherite_axe"}, canCraft: true, canDig: true},
+		{name: "chunky", upgrades: TurtleUpgrades{Right: "advancedperipherals:chunk_controller"}, chunkLoad: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			//act
+			u := tt.upgrades
+
+			//assert
+			t.Logf("actual: %v %v %v %v", u.CanCraft(), u.CanDig(), u.IsWireless(), u.CanLoadChunks())
+			if u.CanCraft() != tt.canCraft || u.CanDig() != tt.canDig || u.CanAttack() != tt.canDig ||
+				u.IsWireless() != tt.wireless || u.CanLoadChunks() != tt.chunkLoad {
+				t.FailNow()
+			}
+		})
+	}
+}
+
+func TestTurtle_Upgrades(t *testing.T) {
+	//arrange
+	conn := &test.ConnectionMock{}
+	conn.Respond([]any{nil, "minecraft:crafting_table"})
+	var expected = TurtleUpgrades{Right: UpgradeCraftingTable}
+
+	//act
+	actual, err := NewTurtle(conn).Upgrades(context.TODO())
+
+	//assert
+	t.Logf("expected: %v", expected)
+	t.Logf("actual  : %v", actual)
+	if err != nil || actual != expected {
+		t.FailNow()
+	}
+	if side, ok := actual.Side(UpgradeCraftingTable); !ok || side != SideRight {
+		t.FailNow()
+	}
+}
@@ -5,29 +5,39 @@ import (
 	"errors"
 )
 
+// DoActionBool executes a command, that returns whether it succeeded and optionally the reason
+// why it failed (e.g. `false, "Movement obstructed"`). The reason is returned as *ActionError.
 func DoActionBool(ctx context.Context, conn Connection, command string) (bool, error) {
+	return DoAction(ctx, conn, command, nil)
+}
+
+// DoAction is like DoActionBool, but the reason is parsed with parse into the Err of the ActionError,
+// so it can be checked with errors.Is.
+func DoAction(ctx context.Context, conn Connection, command string, parse func(reason string) error) (bool, error) {
 	res, err := conn.Execute(ctx, command)
 	if err != nil {
 		return false, RpcError(err)
 	}
 
-	if res != nil && len(res) > 1 {
-		err, ok := res[1].(string)
-		if ok {
-			return false, RpcError(errors.New(err))
-		}
-	}
-
 	if len(res) < 1 {
 		return false, RpcError(errors.New("unexpected data length"))
 	}
 
-	detect, ok := res[0].(bool)
-	if ok {
-		return detect, nil
-	} else {
+	success, ok := res[0].(bool)
+	if !ok {
 		return false, UnexpectedDatatypeErr
 	}
+
+	if !success && len(res) > 1 {
+		if reason, ok := res[1].(string); ok {
+			actionErr := &ActionError{Command: command, Reason: reason}
+			if parse != nil {
+				actionErr.Err = parse(reason)
+			}
+			return false, actionErr
+		}
+	}
+	return success, nil
 }
 
 func DoActionInt(ctx context.Context, conn Connection, command string) (int, error) {
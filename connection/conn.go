@@ -1,6 +1,7 @@
 package connection
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -8,10 +9,19 @@ import (
 	"go.uber.org/zap"
 	"sync"
 	"time"
+	"unicode/utf8"
 )
 
 type Connection interface {
 	Execute(ctx context.Context, command string) (response []any, err error)
+	// Subscribe returns a channel receiving all events with one of the given names,
+	// until ctx is done. The channel is closed afterwards.
+	Subscribe(ctx context.Context, events ...string) (<-chan Event, error)
+}
+
+type reply struct {
+	values []interface{}
+	err    error
 }
 
 type connection struct {
@@ -19,19 +29,53 @@ type connection struct {
 	Out chan<- []byte
 	log *zap.SugaredLogger
 	mu  sync.Mutex
+
+	responses chan reply
+	closed    chan struct{}
+	events    *eventBus
 }
 
 func New(in <-chan []byte, out chan<- []byte, opts ...Option) (conn *connection) {
 	o := ParseOptions(opts)
 
 	c := &connection{
-		In:  in,
-		Out: out,
-		log: o.Log.With("connId", uuid.New().String()),
+		In:        in,
+		Out:       out,
+		log:       o.Log.With("connId", uuid.New().String()),
+		responses: make(chan reply, 1),
+		closed:    make(chan struct{}),
+		events:    newEventBus(),
 	}
+	go c.dispatch()
 	return c
 }
 
+// dispatch reads all incoming messages and routes them either to the pending
+// execution or to the event subscribers.
+func (c *connection) dispatch() {
+	defer close(c.closed)
+	for buffer := range c.In {
+		event, isEvent := decodeEvent(buffer)
+		if isEvent {
+			c.log.Debugf("received event: \"%v\"", event.Name)
+			c.events.publish(event)
+			continue
+		}
+
+		values, err := decodeResponse(buffer)
+		if err != nil {
+			c.log.Error(err)
+		}
+
+		select {
+		case c.responses <- reply{values: values, err: err}:
+		default:
+			c.log.Warnw("dropped response without pending execution", "response", values)
+		}
+	}
+	c.log.Warn("incoming channel was closed")
+}
+
 func (c *connection) send(f string) (err error) {
 	defer func() {
 		if x := recover(); x != nil {
@@ -39,41 +83,31 @@ func (c *connection) send(f string) (err error) {
 		}
 	}()
 
+	msg, err := encodeInstruction(f)
+	if err != nil {
+		return err
+	}
+
 	c.log.Debugf("sending instruction: \"%v\"", f)
-	c.Out <- []byte(fmt.Sprintf(`{"func": "return {%s}"}`, f))
+	c.Out <- msg
 	c.log.Debugf("send instruction \"%v\"", f)
 	return err
 }
 
 func (c *connection) receive(ctx context.Context) ([]interface{}, error) {
 	c.log.Debug("waiting for incoming message")
-	res := make(chan []interface{})
-	var e error
-	go func() {
-		buffer, ok := <-c.In
-		if !ok {
-			c.log.Warn("tried to receive response on closed channel")
-			e = ClosedChannelErr
-			res <- []interface{}{}
-			return
-		}
-
-		response := make([]interface{}, 0)
-		err := json.Unmarshal(buffer, &response)
-		if err != nil {
-			c.log.Error(err)
-			e = err
-			res <- []interface{}{}
-		}
-
-		e = nil
-		res <- response
-	}()
-
 	select {
-	case r := <-res:
-		c.log.Debugf("received message: \"%v\"", r)
-		return r, e
+	case r := <-c.responses:
+		c.log.Debugf("received message: \"%v\"", r.values)
+		return r.values, r.err
+	case <-c.closed:
+		select {
+		case r := <-c.responses:
+			return r.values, r.err
+		default:
+		}
+		c.log.Warn("tried to receive response on closed channel")
+		return []interface{}{}, ClosedChannelErr
 	case <-ctx.Done():
 		err := ctx.Err()
 		c.log.Debugw("waiting for incoming message timed out!", "err", err)
@@ -81,6 +115,76 @@ func (c *connection) receive(ctx context.Context) ([]interface{}, error) {
 	}
 }
 
+// encodeInstruction wraps a lua expression into an instruction message
+func encodeInstruction(f string) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	err := enc.Encode(map[string]string{"func": fmt.Sprintf("return {%s}", f)})
+	if err != nil {
+		return nil, err
+	}
+	return bytes.TrimSpace(buf.Bytes()), nil
+}
+
+// decodeResponse parses a response message. Responses are either an array of return
+// values or an object with an "err" key, if the instruction failed on the device.
+func decodeResponse(buffer []byte) ([]interface{}, error) {
+	var message interface{}
+	err := json.Unmarshal(buffer, &message)
+	if err != nil {
+		return nil, err
+	}
+
+	switch msg := message.(type) {
+	case []interface{}:
+		return fromLatin1(msg).([]interface{}), nil
+	case map[string]interface{}:
+		if errMsg, ok := msg["err"]; ok {
+			return nil, fmt.Errorf("%v", fromLatin1(errMsg))
+		}
+		if len(msg) == 0 {
+			return []interface{}{}, nil
+		}
+	}
+	return nil, UnexpectedDatatypeErr
+}
+
+// fromLatin1 restores the original bytes of all strings in a response. The lua runtime
+// escapes every byte outside of printable ascii as \u00XX, so each rune is one byte.
+func fromLatin1(v interface{}) interface{} {
+	switch val := v.(type) {
+	case string:
+		for i := 0; i < len(val); i++ {
+			if val[i] >= utf8.RuneSelf {
+				buf := make([]byte, 0, len(val))
+				for _, r := range val {
+					if r < 0x100 {
+						buf = append(buf, byte(r))
+					} else {
+						buf = utf8.AppendRune(buf, r)
+					}
+				}
+				return string(buf)
+			}
+		}
+		return val
+	case []interface{}:
+		for i, item := range val {
+			val[i] = fromLatin1(item)
+		}
+		return val
+	case map[string]interface{}:
+		m := make(map[string]interface{}, len(val))
+		for k, item := range val {
+			m[fromLatin1(k).(string)] = fromLatin1(item)
+		}
+		return m
+	default:
+		return v
+	}
+}
+
 func (c *connection) Execute(ctx context.Context, command string) (response []interface{}, err error) {
 	start := time.Now()
 	executionId := uuid.New().String()
@@ -89,6 +193,13 @@ func (c *connection) Execute(ctx context.Context, command string) (response []in
 	log.Infof("Execution started: %v", command)
 	c.mu.Lock()
 	defer c.mu.Unlock()
+
+	// discard a late response of a previous execution that timed out
+	select {
+	case <-c.responses:
+	default:
+	}
+
 	err = c.send(command)
 	if err != nil {
 		log.Errorw("Execution failed", "err", err)
@@ -6,6 +6,7 @@ import (
 	"github.com/m4schini/logger"
 	"sync"
 	"testing"
+	"time"
 )
 
 func TestConn_Execute(t *testing.T) {
@@ -138,3 +139,108 @@ func TestSendOnClosedChannel(t *testing.T) {
 
 	t.Logf("Err: %v (%T)", err, err)
 }
+
+func TestConn_Subscribe(t *testing.T) {
+	//arrange
+	var wg sync.WaitGroup
+	var events <-chan Event
+	var err error
+	in := make(chan []byte)
+	out := make(chan []byte)
+	conn := New(in, out)
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+
+	//act
+	wg.Add(1)
+	go func() {
+		events, err = conn.Subscribe(ctx, "redstone")
+		wg.Done()
+	}()
+
+	outgoing := <-out
+	t.Logf("<- outgoing: %v", string(outgoing))
+	in <- []byte(`[]`)
+	wg.Wait()
+	in <- []byte(`{"event": ["redstone"]}`)
+	in <- []byte(`{"event": ["rednet_message", 4, "hi"]}`)
+	in <- []byte(`{"event": ["redstone"]}`)
+	first := <-events
+	second := <-events
+
+	//assert
+	if err != nil || first.Name != "redstone" || second.Name != "redstone" {
+		t.FailNow()
+	}
+}
+
+func TestConn_Execute_interleavedEvent(t *testing.T) {
+	//arrange
+	var expected = `test`
+	var actual []interface{}
+	var wg sync.WaitGroup
+	var err error
+	in := make(chan []byte)
+	out := make(chan []byte)
+	conn := New(in, out)
+
+	//act
+	wg.Add(1)
+	go func() {
+		actual, err = conn.Execute(context.TODO(), "test")
+		wg.Done()
+	}()
+
+	<-out
+	in <- []byte(`{"event": ["timer", 1]}`)
+	in <- []byte(fmt.Sprintf(`["%v"]`, expected))
+	wg.Wait()
+
+	//assert
+	t.Logf("expected: %v", expected)
+	t.Logf("  actual: %v", actual)
+	if err != nil || len(actual) != 1 || actual[0] != expected {
+		t.FailNow()
+	}
+}
+
+func TestConn_Subscribe_failed(t *testing.T) {
+	//arrange
+	var wg sync.WaitGroup
+	var err error
+	in := make(chan []byte)
+	out := make(chan []byte)
+	conn := New(in, out)
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	wg.Add(1)
+	go func() {
+		_, err = conn.Subscribe(ctx, "redstone")
+		wg.Done()
+	}()
+	<-out
+	in <- []byte(`[]`)
+	wg.Wait()
+
+	//act
+	wg.Add(1)
+	go func() {
+		_, err = conn.Subscribe(ctx, "redstone", "timer")
+		wg.Done()
+	}()
+	outgoing := <-out
+	t.Logf("<- outgoing: %v", string(outgoing))
+	in <- []byte(`{"err": "attempt to call nil"}`)
+	wg.Wait()
+
+	//assert
+	select {
+	case outgoing = <-out:
+		t.Logf("<- outgoing: %v", string(outgoing))
+		t.FailNow()
+	case <-time.After(50 * time.Millisecond):
+	}
+	if err == nil {
+		t.FailNow()
+	}
+}
@@ -12,3 +12,20 @@ func RpcError(err error) error {
 var ClosedChannelErr = fmt.Errorf("channel is closed")
 
 var UnexpectedDatatypeErr = fmt.Errorf("unexpected datatype")
+
+// ActionError is returned if an action failed with a reason, like `false, "Movement obstructed"`.
+// The command itself was executed, so it is not an RpcError.
+type ActionError struct {
+	Command string
+	Reason  string
+	// Err is the error the reason was parsed into, it is nil if the reason is unknown
+	Err error
+}
+
+func (e *ActionError) Error() string {
+	return e.Reason
+}
+
+func (e *ActionError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,203 @@
+package connection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RuntimeModuleName is the name of the global table of the lua runtime (see lua/startup.lua)
+const RuntimeModuleName = "ccgo"
+
+// unsubscribeTimeout limits how long the runtime is told to stop forwarding an event
+const unsubscribeTimeout = 5 * time.Second
+
+// Event is a computercraft event (see os.pullEvent) forwarded by the lua runtime.
+type Event struct {
+	Name string
+	Args []any
+}
+
+// Arg decodes the i-th argument of the event (starting at 0) into out.
+func (e Event) Arg(i int, out any) error {
+	var in any
+	if i < len(e.Args) {
+		in = e.Args[i]
+	}
+	return Decode(in, out)
+}
+
+// decodeEvent parses an event message. Events are objects with an "event" key,
+// containing the name and the arguments of the event.
+func decodeEvent(buffer []byte) (Event, bool) {
+	var message struct {
+		Event []any `json:"event"`
+	}
+	err := json.Unmarshal(buffer, &message)
+	if err != nil || len(message.Event) < 1 {
+		return Event{}, false
+	}
+
+	values := fromLatin1(message.Event).([]any)
+	name, ok := values[0].(string)
+	if !ok {
+		return Event{}, false
+	}
+	return Event{Name: name, Args: values[1:]}, true
+}
+
+type subscription struct {
+	names  map[string]struct{}
+	out    chan Event
+	notify chan struct{}
+
+	mu    sync.Mutex
+	queue []Event
+}
+
+// forward delivers queued events to the subscriber. Events are queued without limit,
+// so slow subscribers never block the dispatching of responses.
+func (s *subscription) forward(ctx context.Context) {
+	defer close(s.out)
+	for {
+		s.mu.Lock()
+		queue := s.queue
+		s.queue = nil
+		s.mu.Unlock()
+
+		for _, event := range queue {
+			select {
+			case s.out <- event:
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		select {
+		case <-s.notify:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+type eventBus struct {
+	mu            sync.Mutex
+	subscriptions map[*subscription]struct{}
+	refs          map[string]int
+
+	// remote is held while the reference counts are changed and the runtime is told about it, so
+	// the subscriptions of the runtime always match the reference counts. It is separate from mu,
+	// because events have to be published while the runtime is called.
+	remote sync.Mutex
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{
+		subscriptions: make(map[*subscription]struct{}),
+		refs:          make(map[string]int),
+	}
+}
+
+func (b *eventBus) publish(event Event) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for s := range b.subscriptions {
+		if _, ok := s.names[event.Name]; !ok {
+			continue
+		}
+		s.mu.Lock()
+		s.queue = append(s.queue, event)
+		s.mu.Unlock()
+		select {
+		case s.notify <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// add registers a subscription and returns the event names that had no subscribers before
+func (b *eventBus) add(s *subscription) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions[s] = struct{}{}
+	added := make([]string, 0)
+	for name := range s.names {
+		b.refs[name]++
+		if b.refs[name] == 1 {
+			added = append(added, name)
+		}
+	}
+	return added
+}
+
+// remove unregisters a subscription and returns the event names that have no subscribers left
+func (b *eventBus) remove(s *subscription) []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subscriptions, s)
+	removed := make([]string, 0)
+	for name := range s.names {
+		b.refs[name]--
+		if b.refs[name] <= 0 {
+			delete(b.refs, name)
+			removed = append(removed, name)
+		}
+	}
+	return removed
+}
+
+func (c *connection) Subscribe(ctx context.Context, events ...string) (<-chan Event, error) {
+	s := &subscription{
+		names:  make(map[string]struct{}, len(events)),
+		out:    make(chan Event),
+		notify: make(chan struct{}, 1),
+	}
+	for _, name := range events {
+		s.names[name] = struct{}{}
+	}
+
+	c.events.remote.Lock()
+	added := c.events.add(s)
+	for i, name := range added {
+		_, err := c.Execute(ctx, fmt.Sprintf("%v.subscribe(%v)", RuntimeModuleName, LuaString(name)))
+		if err != nil {
+			// only the events the runtime was subscribed to are unsubscribed again
+			c.events.remove(s)
+			c.unsubscribeRemote(added[:i])
+			c.events.remote.Unlock()
+			return nil, RpcError(err)
+		}
+	}
+	c.events.remote.Unlock()
+
+	go func() {
+		s.forward(ctx)
+		c.unsubscribe(s)
+	}()
+	return s.out, nil
+}
+
+func (c *connection) unsubscribe(s *subscription) {
+	c.events.remote.Lock()
+	defer c.events.remote.Unlock()
+	c.unsubscribeRemote(c.events.remove(s))
+}
+
+// unsubscribeRemote tells the runtime to stop forwarding the events
+func (c *connection) unsubscribeRemote(names []string) {
+	if len(names) == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), unsubscribeTimeout)
+	defer cancel()
+	for _, name := range names {
+		_, err := c.Execute(ctx, fmt.Sprintf("%v.unsubscribe(%v)", RuntimeModuleName, LuaString(name)))
+		if err != nil {
+			c.log.Warnw("failed to unsubscribe from event", "event", name, "err", err)
+		}
+	}
+}
@@ -0,0 +1,467 @@
+package connection
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LuaExpr is lua code that is inserted into a command as is, e.g. `colors.red`.
+type LuaExpr string
+
+// LuaValue converts a go value into a lua literal. Strings are escaped byte by byte,
+// slices become sequences and maps and structs become tables. Struct fields can be
+// renamed with a `lua:"name"` tag and skipped with `lua:"-"`.
+func LuaValue(v any) (string, error) {
+	var sb strings.Builder
+	err := writeLuaValue(&sb, reflect.ValueOf(v))
+	if err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// LuaArgs converts a list of go values into a comma separated list of lua literals,
+// suitable as arguments of a lua function call.
+func LuaArgs(args ...any) (string, error) {
+	values := make([]string, len(args))
+	for i, arg := range args {
+		value, err := LuaValue(arg)
+		if err != nil {
+			return "", err
+		}
+		values[i] = value
+	}
+	return strings.Join(values, ", "), nil
+}
+
+// LuaString quotes s as a lua string literal. All non-printable bytes are escaped,
+// so arbitrary binary data can be transferred.
+func LuaString(s string) string {
+	var sb strings.Builder
+	writeLuaString(&sb, s)
+	return sb.String()
+}
+
+var luaExprType = reflect.TypeOf(LuaExpr(""))
+
+func writeLuaValue(sb *strings.Builder, v reflect.Value) error {
+	if !v.IsValid() {
+		sb.WriteString("nil")
+		return nil
+	}
+	if v.Type() == luaExprType {
+		sb.WriteString(v.String())
+		return nil
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			sb.WriteString("nil")
+			return nil
+		}
+		return writeLuaValue(sb, v.Elem())
+	case reflect.Bool:
+		sb.WriteString(strconv.FormatBool(v.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		sb.WriteString(strconv.FormatInt(v.Int(), 10))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		sb.WriteString(strconv.FormatUint(v.Uint(), 10))
+	case reflect.Float32, reflect.Float64:
+		f := v.Float()
+		switch {
+		case math.IsNaN(f):
+			sb.WriteString("(0/0)")
+		case math.IsInf(f, 1):
+			sb.WriteString("math.huge")
+		case math.IsInf(f, -1):
+			sb.WriteString("-math.huge")
+		default:
+			sb.WriteString(strconv.FormatFloat(f, 'g', -1, 64))
+		}
+	case reflect.String:
+		writeLuaString(sb, v.String())
+	case reflect.Slice:
+		if v.IsNil() {
+			sb.WriteString("nil")
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			writeLuaString(sb, string(v.Bytes()))
+			return nil
+		}
+		return writeLuaSequence(sb, v)
+	case reflect.Array:
+		return writeLuaSequence(sb, v)
+	case reflect.Map:
+		if v.IsNil() {
+			sb.WriteString("nil")
+			return nil
+		}
+		return writeLuaMap(sb, v)
+	case reflect.Struct:
+		return writeLuaStruct(sb, v)
+	default:
+		return fmt.Errorf("%w: %v cannot be converted to lua", UnexpectedDatatypeErr, v.Type())
+	}
+	return nil
+}
+
+func writeLuaString(sb *strings.Builder, s string) {
+	sb.WriteByte('"')
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch c {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if c < 0x20 || c >= 0x7f {
+				fmt.Fprintf(sb, `\%03d`, c)
+			} else {
+				sb.WriteByte(c)
+			}
+		}
+	}
+	sb.WriteByte('"')
+}
+
+func writeLuaSequence(sb *strings.Builder, v reflect.Value) error {
+	sb.WriteByte('{')
+	for i := 0; i < v.Len(); i++ {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		err := writeLuaValue(sb, v.Index(i))
+		if err != nil {
+			return err
+		}
+	}
+	sb.WriteByte('}')
+	return nil
+}
+
+func writeLuaMap(sb *strings.Builder, v reflect.Value) error {
+	keys := make([]string, 0, v.Len())
+	values := make(map[string]reflect.Value, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		var key strings.Builder
+		err := writeLuaValue(&key, iter.Key())
+		if err != nil {
+			return err
+		}
+		keys = append(keys, key.String())
+		values[key.String()] = iter.Value()
+	}
+	sort.Strings(keys)
+
+	sb.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString("[" + key + "] = ")
+		err := writeLuaValue(sb, values[key])
+		if err != nil {
+			return err
+		}
+	}
+	sb.WriteByte('}')
+	return nil
+}
+
+func writeLuaStruct(sb *strings.Builder, v reflect.Value) error {
+	sb.WriteByte('{')
+	_, err := writeLuaFields(sb, v, true)
+	if err != nil {
+		return err
+	}
+	sb.WriteByte('}')
+	return nil
+}
+
+// writeLuaFields writes the fields of a struct as table entries. Fields of embedded
+// structs are written as if they were fields of the outer struct.
+func writeLuaFields(sb *strings.Builder, v reflect.Value, first bool) (bool, error) {
+	for i := 0; i < v.NumField(); i++ {
+		field := v.Type().Field(i)
+		if isEmbedded(field) {
+			var err error
+			first, err = writeLuaFields(sb, v.Field(i), first)
+			if err != nil {
+				return first, err
+			}
+			continue
+		}
+		name, ok := luaFieldName(field)
+		if !ok {
+			continue
+		}
+		if !first {
+			sb.WriteString(", ")
+		}
+		first = false
+		sb.WriteString("[")
+		writeLuaString(sb, name)
+		sb.WriteString("] = ")
+		err := writeLuaValue(sb, v.Field(i))
+		if err != nil {
+			return first, err
+		}
+	}
+	return first, nil
+}
+
+func isEmbedded(field reflect.StructField) bool {
+	return field.Anonymous && field.Type.Kind() == reflect.Struct && field.IsExported() && field.Tag.Get("lua") == ""
+}
+
+func luaFieldName(field reflect.StructField) (string, bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	tag := field.Tag.Get("lua")
+	if tag == "-" {
+		return "", false
+	}
+	if tag != "" {
+		return tag, true
+	}
+	return field.Name, true
+}
+
+// Decode stores a value returned by the lua runtime in the value pointed to by out.
+// Lua tables are accepted for slices, maps and structs regardless of whether they
+// were transferred as json arrays or objects. Struct fields are matched with the
+// same `lua` tags used by LuaValue.
+func Decode(in any, out any) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("decode target must be a non-nil pointer, got %T", out)
+	}
+	return decode(in, rv.Elem())
+}
+
+func decode(in any, out reflect.Value) error {
+	if in == nil {
+		out.Set(reflect.Zero(out.Type()))
+		return nil
+	}
+
+	mismatch := func() error {
+		return fmt.Errorf("%w: cannot decode %T into %v", UnexpectedDatatypeErr, in, out.Type())
+	}
+
+	switch out.Kind() {
+	case reflect.Interface:
+		if out.NumMethod() != 0 {
+			return mismatch()
+		}
+		out.Set(reflect.ValueOf(in))
+	case reflect.Pointer:
+		v := reflect.New(out.Type().Elem())
+		err := decode(in, v.Elem())
+		if err != nil {
+			return err
+		}
+		out.Set(v)
+	case reflect.Bool:
+		b, ok := in.(bool)
+		if !ok {
+			return mismatch()
+		}
+		out.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f, ok := in.(float64)
+		if !ok {
+			return mismatch()
+		}
+		out.SetInt(int64(f))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f, ok := in.(float64)
+		if !ok || f < 0 {
+			return mismatch()
+		}
+		out.SetUint(uint64(f))
+	case reflect.Float32, reflect.Float64:
+		f, ok := in.(float64)
+		if !ok {
+			return mismatch()
+		}
+		out.SetFloat(f)
+	case reflect.String:
+		s, ok := in.(string)
+		if !ok {
+			return mismatch()
+		}
+		out.SetString(s)
+	case reflect.Slice:
+		if s, ok := in.(string); ok && out.Type().Elem().Kind() == reflect.Uint8 {
+			out.SetBytes([]byte(s))
+			return nil
+		}
+		items, ok := sequence(in)
+		if !ok {
+			return mismatch()
+		}
+		slice := reflect.MakeSlice(out.Type(), len(items), len(items))
+		for i, item := range items {
+			err := decode(item, slice.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+		out.Set(slice)
+	case reflect.Array:
+		items, ok := sequence(in)
+		if !ok || len(items) > out.Len() {
+			return mismatch()
+		}
+		array := reflect.New(out.Type()).Elem()
+		for i, item := range items {
+			err := decode(item, array.Index(i))
+			if err != nil {
+				return err
+			}
+		}
+		out.Set(array)
+	case reflect.Map:
+		entries, ok := table(in)
+		if !ok {
+			return mismatch()
+		}
+		m := reflect.MakeMapWithSize(out.Type(), len(entries))
+		for k, item := range entries {
+			key := reflect.New(out.Type().Key()).Elem()
+			switch key.Kind() {
+			case reflect.String:
+				key.SetString(k)
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				i, err := strconv.ParseInt(k, 10, 64)
+				if err != nil {
+					return mismatch()
+				}
+				key.SetInt(i)
+			case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+				u, err := strconv.ParseUint(k, 10, 64)
+				if err != nil {
+					return mismatch()
+				}
+				key.SetUint(u)
+			default:
+				return mismatch()
+			}
+			value := reflect.New(out.Type().Elem()).Elem()
+			err := decode(item, value)
+			if err != nil {
+				return err
+			}
+			m.SetMapIndex(key, value)
+		}
+		out.Set(m)
+	case reflect.Struct:
+		entries, ok := table(in)
+		if !ok {
+			return mismatch()
+		}
+		for i := 0; i < out.NumField(); i++ {
+			field := out.Type().Field(i)
+			if isEmbedded(field) {
+				err := decode(in, out.Field(i))
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			name, ok := luaFieldName(field)
+			if !ok {
+				continue
+			}
+			item, ok := entries[name]
+			if !ok {
+				continue
+			}
+			err := decode(item, out.Field(i))
+			if err != nil {
+				return err
+			}
+		}
+	default:
+		return mismatch()
+	}
+	return nil
+}
+
+// sequence converts a lua table into a list. Tables with integer keys are
+// transferred as json objects if they are sparse.
+func sequence(in any) ([]any, bool) {
+	switch v := in.(type) {
+	case []any:
+		return v, true
+	case map[string]any:
+		max := 0
+		for k := range v {
+			i, err := strconv.Atoi(k)
+			if err != nil || i < 1 {
+				return nil, false
+			}
+			if i > max {
+				max = i
+			}
+		}
+		items := make([]any, max)
+		for k, item := range v {
+			i, _ := strconv.Atoi(k)
+			items[i-1] = item
+		}
+		return items, true
+	default:
+		return nil, false
+	}
+}
+
+// table converts a lua table into a map. Lists are keyed by their (lua) index.
+func table(in any) (map[string]any, bool) {
+	switch v := in.(type) {
+	case map[string]any:
+		return v, true
+	case []any:
+		entries := make(map[string]any, len(v))
+		for i, item := range v {
+			if item != nil {
+				entries[strconv.Itoa(i+1)] = item
+			}
+		}
+		return entries, true
+	default:
+		return nil, false
+	}
+}
+
+// Unpack decodes the return values of a lua function into outs, in order. Missing
+// return values are treated as nil.
+func Unpack(res []any, outs ...any) error {
+	for i, out := range outs {
+		var in any
+		if i < len(res) {
+			in = res[i]
+		}
+		err := Decode(in, out)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
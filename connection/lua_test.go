@@ -0,0 +1,97 @@
+package connection
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestLuaValue(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected string
+	}{
+		{nil, "nil"},
+		{true, "true"},
+		{5, "5"},
+		{uint8(7), "7"},
+		{1.5, "1.5"},
+		{math.Inf(1), "math.huge"},
+		{"say \"hi\"\n", `"say \"hi\"\n"`},
+		{"\x00\xff", `"\000\255"`},
+		{[]byte("ab"), `"ab"`},
+		{[]int{1, 2, 3}, "{1, 2, 3}"},
+		{map[string]int{"b": 2, "a": 1}, `{["a"] = 1, ["b"] = 2}`},
+		{struct {
+			Name  string `lua:"name"`
+			Count int
+			skip  int
+		}{"x", 3, 0}, `{["name"] = "x", ["Count"] = 3}`},
+		{LuaExpr("colors.red"), "colors.red"},
+	}
+
+	for _, test := range tests {
+		actual, err := LuaValue(test.value)
+		t.Logf("expected: %v", test.expected)
+		t.Logf("  actual: %v", actual)
+		if err != nil || actual != test.expected {
+			t.FailNow()
+		}
+	}
+}
+
+func TestLuaValue_unsupported(t *testing.T) {
+	_, err := LuaValue(make(chan int))
+	if !errors.Is(err, UnexpectedDatatypeErr) {
+		t.FailNow()
+	}
+}
+
+func TestDecode(t *testing.T) {
+	//arrange
+	type item struct {
+		Name  string          `lua:"name"`
+		Count int             `lua:"count"`
+		Tags  map[string]bool `lua:"tags"`
+	}
+	in := map[string]any{
+		"1": map[string]any{"name": "minecraft:dirt", "count": float64(64), "tags": []any{}},
+		"3": map[string]any{"name": "minecraft:stone", "count": float64(1), "tags": map[string]any{"c:stones": true}},
+	}
+
+	//act
+	var actual []*item
+	err := Decode(in, &actual)
+
+	//assert
+	t.Logf("actual: %v", actual)
+	if err != nil || len(actual) != 3 {
+		t.FailNow()
+	}
+	if actual[0].Name != "minecraft:dirt" || actual[0].Count != 64 || actual[1] != nil || !actual[2].Tags["c:stones"] {
+		t.FailNow()
+	}
+}
+
+func TestDecodeResponse_latin1(t *testing.T) {
+	//arrange
+	var expected = "\xc3\xa9\xff"
+
+	//act
+	actual, err := decodeResponse([]byte(`["Ã©ÿ"]`))
+
+	//assert
+	t.Logf("expected: %q", expected)
+	t.Logf("  actual: %q", actual)
+	if err != nil || len(actual) != 1 || actual[0] != expected {
+		t.FailNow()
+	}
+}
+
+func TestDecodeResponse_err(t *testing.T) {
+	_, err := decodeResponse([]byte(`{"err": "attempt to call nil"}`))
+	t.Logf("Error: %v", err)
+	if err == nil {
+		t.FailNow()
+	}
+}
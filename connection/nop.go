@@ -14,3 +14,12 @@ func NewNopConnection() (conn *nop) {
 func (n *nop) Execute(ctx context.Context, command string) ([]interface{}, error) {
 	return []interface{}{0}, nil
 }
+
+func (n *nop) Subscribe(ctx context.Context, events ...string) (<-chan Event, error) {
+	ch := make(chan Event)
+	go func() {
+		<-ctx.Done()
+		close(ch)
+	}()
+	return ch, nil
+}
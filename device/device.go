@@ -7,6 +7,8 @@ type Capability string
 const (
 	CapabilityGPS    Capability = "gps"
 	CapabilityPocket Capability = "pocket"
+	// CapabilityCommands is available on command computers, see the commands package
+	CapabilityCommands Capability = "commands"
 )
 
 type Device interface {
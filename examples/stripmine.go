@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/gorilla/websocket"
 	"github.com/m4schini/computercraft-go/computer"
@@ -41,29 +42,40 @@ func main() {
 	}
 }
 
-func sliceForward(ctx context.Context, t computer.Turtle) error {
-	dig := func() error {
-		_, err := t.Dig(ctx)
-		return err
-	}
+// maxObstructions limits how often falling blocks (e.g. gravel or sand) are dug before moving forward
+const maxObstructions = 16
 
-	digUp := func() error {
-		_, err := t.DigUp(ctx)
+func sliceForward(ctx context.Context, t computer.Turtle) error {
+	dig := func(dig func(context.Context, ...computer.Side) (bool, error)) error {
+		_, err := dig(ctx)
+		if errors.Is(err, computer.ErrNothingToDig) {
+			return nil
+		}
 		return err
 	}
 
 	forward := func() error {
-		ok, err := t.Forward(ctx)
-		if err != nil {
-			return err
-		}
-		if !ok {
-			return fmt.Errorf("couldn't move")
+		for i := 0; i < maxObstructions; i++ {
+			ok, err := t.Forward(ctx)
+			if ok {
+				return nil
+			}
+			if err == nil {
+				return errors.New("couldn't move forward")
+			}
+			if !errors.Is(err, computer.ErrMovementObstructed) {
+				return err
+			}
+			// gravel or sand fell into the dug block, bedrock fails with ErrUnbreakable
+			err = dig(t.Dig)
+			if err != nil {
+				return err
+			}
 		}
-		return nil
+		return fmt.Errorf("couldn't move: %w", computer.ErrMovementObstructed)
 	}
 
-	err := dig()
+	err := dig(t.Dig)
 	if err != nil {
 		return err
 	}
@@ -73,8 +85,7 @@ func sliceForward(ctx context.Context, t computer.Turtle) error {
 		return err
 	}
 
-	err = digUp()
-	return err
+	return dig(t.DigUp)
 }
 
 func strip(ctx context.Context, t computer.Turtle, length int) (int, error) {
@@ -93,6 +104,10 @@ func StripMine(t computer.Turtle, iterations int) {
 
 	for i := 0; i < iterations; i++ {
 		_, err := strip(ctx, t, 3)
+		if errors.Is(err, computer.ErrUnbreakable) {
+			log.Println("reached bedrock")
+			return
+		}
 		if err != nil {
 			panic(err)
 		}
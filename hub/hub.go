@@ -0,0 +1,212 @@
+package hub
+
+import (
+	"context"
+	"github.com/gorilla/websocket"
+	"github.com/m4schini/computercraft-go/computer"
+	"github.com/m4schini/computercraft-go/connection"
+	"github.com/m4schini/computercraft-go/connection/adapter"
+	"net/http"
+	"path"
+	"sort"
+	"sync"
+	"time"
+)
+
+// IdentifyTimeout is the time a device has to answer the identification after connecting
+const IdentifyTimeout = 10 * time.Second
+
+// Device is a computer, turtle or pocket computer connected to the hub
+type Device struct {
+	ID        string
+	Label     string
+	Type      computer.DeviceType
+	Conn      connection.Connection
+	Connected time.Time
+
+	done chan struct{}
+}
+
+// Done is closed when the device disconnects
+func (d *Device) Done() <-chan struct{} {
+	return d.done
+}
+
+// Filter selects devices, e.g. for Hub.Select
+type Filter func(d *Device) bool
+
+// LabelGlob selects all devices with a label matching the pattern (see path.Match).
+// Devices without a label never match, unless the pattern is empty.
+func LabelGlob(pattern string) Filter {
+	return func(d *Device) bool {
+		if pattern == "" {
+			return true
+		}
+		ok, _ := path.Match(pattern, d.Label)
+		return ok && d.Label != ""
+	}
+}
+
+// OfType selects all devices of one of the given types. Without types all devices are selected.
+func OfType(types ...computer.DeviceType) Filter {
+	return func(d *Device) bool {
+		if len(types) == 0 {
+			return true
+		}
+		for _, t := range types {
+			if d.Type == t {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Hub accepts websocket connections of the lua runtime and keeps track of all connected devices.
+// It is a http.Handler, the runtime connects to "/api/ws".
+type Hub struct {
+	upgrader websocket.Upgrader
+	opts     []connection.Option
+
+	mu        sync.Mutex
+	devices   map[*Device]struct{}
+	listeners []chan<- *Device
+}
+
+func New(opts ...connection.Option) *Hub {
+	return &Hub{
+		upgrader: websocket.Upgrader{
+			CheckOrigin: func(r *http.Request) bool { return true },
+		},
+		opts:    opts,
+		devices: make(map[*Device]struct{}),
+	}
+}
+
+func (h *Hub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	log := connection.ParseOptions(h.opts).Log
+	ws, err := h.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		log.Warnw("websocket upgrade failed", "err", err, "remoteAddr", r.RemoteAddr)
+		return
+	}
+	defer ws.Close()
+
+	in, _ := adapter.ReaderFromWebsocket(ws, adapter.WithLog(log.Desugar()))
+	out := adapter.WriterFromWebsocket(ws, adapter.WithLog(log.Desugar()))
+	defer close(out)
+
+	// messages are forwarded, so the hub notices when the device disconnects
+	forwarded := make(chan []byte)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer close(forwarded)
+		for msg := range in {
+			forwarded <- msg
+		}
+	}()
+
+	d := &Device{
+		Conn:      connection.New(forwarded, out, h.opts...),
+		Connected: time.Now(),
+		done:      done,
+	}
+	err = h.identify(r.Context(), d)
+	if err != nil {
+		log.Warnw("device identification failed", "err", err, "remoteAddr", r.RemoteAddr)
+		return
+	}
+
+	h.add(d)
+	log.Infow("device connected", "id", d.ID, "label", d.Label, "type", d.Type)
+	<-done
+	h.remove(d)
+	log.Infow("device disconnected", "id", d.ID, "label", d.Label, "type", d.Type)
+}
+
+func (h *Hub) identify(ctx context.Context, d *Device) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, IdentifyTimeout)
+	defer cancel()
+
+	d.ID, err = computer.ComputerId(ctx, d.Conn)
+	if err != nil {
+		return err
+	}
+	d.Label, err = computer.ComputerLabel(ctx, d.Conn)
+	if err != nil {
+		return err
+	}
+	d.Type, err = computer.GetDeviceType(ctx, d.Conn)
+	return err
+}
+
+func (h *Hub) add(d *Device) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.devices[d] = struct{}{}
+	for _, listener := range h.listeners {
+		select {
+		case listener <- d:
+		default:
+		}
+	}
+}
+
+func (h *Hub) remove(d *Device) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.devices, d)
+}
+
+// Devices returns all connected devices, ordered by id
+func (h *Hub) Devices() []*Device {
+	return h.Select()
+}
+
+// Select returns all connected devices matching every filter, ordered by id
+func (h *Hub) Select(filters ...Filter) []*Device {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	devices := make([]*Device, 0, len(h.devices))
+outer:
+	for d := range h.devices {
+		for _, filter := range filters {
+			if !filter(d) {
+				continue outer
+			}
+		}
+		devices = append(devices, d)
+	}
+	sort.Slice(devices, func(i, j int) bool {
+		if len(devices[i].ID) != len(devices[j].ID) {
+			return len(devices[i].ID) < len(devices[j].ID)
+		}
+		return devices[i].ID < devices[j].ID
+	})
+	return devices
+}
+
+// Connections returns a channel receiving every device that connects, until ctx is done.
+// Devices are dropped if the channel is not read fast enough.
+func (h *Hub) Connections(ctx context.Context) <-chan *Device {
+	ch := make(chan *Device, 16)
+	h.mu.Lock()
+	h.listeners = append(h.listeners, ch)
+	h.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		for i, listener := range h.listeners {
+			if listener == ch {
+				h.listeners = append(h.listeners[:i], h.listeners[i+1:]...)
+				break
+			}
+		}
+		close(ch)
+	}()
+	return ch
+}
@@ -0,0 +1,24 @@
+package hub
+
+import (
+	"github.com/m4schini/computercraft-go/computer"
+	"testing"
+)
+
+func TestHub_Select(t *testing.T) {
+	//arrange
+	h := New()
+	h.add(&Device{ID: "12", Label: "miner-2", Type: computer.DeviceTurtle})
+	h.add(&Device{ID: "3", Label: "miner-1", Type: computer.DeviceTurtle})
+	h.add(&Device{ID: "4", Label: "miner-base", Type: computer.DeviceComputer})
+	h.add(&Device{ID: "5", Type: computer.DeviceTurtle})
+
+	//act
+	devices := h.Select(LabelGlob("miner-*"), OfType(computer.DeviceTurtle))
+
+	//assert
+	t.Logf("actual: %v", len(devices))
+	if len(devices) != 2 || devices[0].ID != "3" || devices[1].ID != "12" {
+		t.FailNow()
+	}
+}
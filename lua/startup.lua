@@ -1,5 +1,9 @@
 local addr = ""
 
+-- commands are loaded in the environment of this program, so they can use
+-- shell, multishell and require
+local programEnv = _ENV or getfenv(1)
+
 function log(prefix, message)
     local div = " : "
     local time = os.date("%Z%X")
@@ -49,10 +53,391 @@ function setupFromUser()
     return config
 end
 
+local function quote(s)
+    local escaped = s:gsub('[%c"\\\128-\255]', function(c)
+        if c == '"' then
+            return '\\"'
+        elseif c == "\\" then
+            return "\\\\"
+        end
+        return string.format("\\u%04x", c:byte())
+    end)
+    return '"' .. escaped .. '"'
+end
+
+-- toJSON serialises a value like textutils.serialiseJSON, but keeps nil values
+-- inside of lists (as null), sparse lists and strings with arbitrary bytes intact.
+function toJSON(value)
+    local t = type(value)
+    if t == "nil" then
+        return "null"
+    elseif t == "boolean" then
+        return tostring(value)
+    elseif t == "number" then
+        if value ~= value or value == math.huge or value == -math.huge then
+            return "null"
+        elseif value % 1 == 0 and math.abs(value) < 2 ^ 53 then
+            return string.format("%d", value)
+        end
+        return string.format("%.17g", value)
+    elseif t == "string" then
+        return quote(value)
+    elseif t == "table" then
+        local n, max, isList = 0, 0, true
+        for k in pairs(value) do
+            n = n + 1
+            if type(k) == "number" and k >= 1 and k % 1 == 0 then
+                max = math.max(max, k)
+            else
+                isList = false
+            end
+        end
+
+        local parts = {}
+        if isList and max <= n * 2 then
+            for i = 1, max do
+                parts[i] = toJSON(value[i])
+            end
+            return "[" .. table.concat(parts, ",") .. "]"
+        end
+
+        for k, v in pairs(value) do
+            local vt = type(v)
+            if vt ~= "function" and vt ~= "thread" and vt ~= "userdata" then
+                parts[#parts + 1] = quote(tostring(k)) .. ":" .. toJSON(v)
+            end
+        end
+        return "{" .. table.concat(parts, ",") .. "}"
+    end
+    return "null"
+end
+
+-- ccgo is the api of the runtime, that is used by computercraft-go
+ccgo = {
+    subscriptions = {},
+    processes = {},
+    nextPid = 1,
+    nextRequest = 1,
+}
+
+-- programs started by the shell can use the runtime as well, e.g. ccgo.fetch
+_G.ccgo = ccgo
+
+-- emit sends an event to computercraft-go
+function ccgo.emit(name, ...)
+    ws.send(toJSON({ event = { name, ... } }))
+end
+
+-- subscribe starts forwarding all events with the given name
+function ccgo.subscribe(name)
+    ccgo.subscriptions[name] = true
+end
+
+-- unsubscribe stops forwarding all events with the given name
+function ccgo.unsubscribe(name)
+    ccgo.subscriptions[name] = nil
+end
+
+-- resume continues a process. Processes can have their own terminal (proc.term)
+-- and are notified when they stop (proc.onExit).
+local function resume(pid, proc, ...)
+    local previous = proc.term and term.redirect(proc.term)
+    local ok, filter = coroutine.resume(proc.co, ...)
+    if previous then
+        proc.term = term.redirect(previous)
+    end
+
+    if not ok then
+        log("!!", tostring(filter))
+    end
+    if not ok or coroutine.status(proc.co) == "dead" then
+        ccgo.processes[pid] = nil
+        if proc.onExit then
+            proc.onExit(pid, ok, filter)
+        end
+    else
+        proc.filter = filter
+    end
+end
+
+local function start(proc, ...)
+    local pid = ccgo.nextPid
+    ccgo.nextPid = pid + 1
+
+    ccgo.processes[pid] = proc
+    resume(pid, proc, ...)
+    return pid
+end
+
+-- spawn runs fn as a background process alongside the command loop and returns its pid
+function ccgo.spawn(fn, ...)
+    return start({ co = coroutine.create(fn) }, ...)
+end
+
+-- kill terminates a process. It returns false if there is no such process.
+function ccgo.kill(pid)
+    local proc = ccgo.processes[pid]
+    if not proc or pid == ccgo.commands then
+        return false
+    end
+
+    -- give the process a chance to stop gracefully
+    resume(pid, proc, "terminate")
+    if ccgo.processes[pid] == proc then
+        ccgo.processes[pid] = nil
+        if proc.onExit then
+            proc.onExit(pid, false, "Terminated")
+        end
+    end
+    return true
+end
+
+-- capture returns a terminal, that behaves like an invisible window and records
+-- all text written to it
+local function capture(parent)
+    local w, h = parent.getSize()
+    local win = window.create(parent, 1, 1, w, h, false)
+    local out = {}
+
+    local t = {}
+    for name, fn in pairs(win) do
+        t[name] = fn
+    end
+    t.write = function(text)
+        out[#out + 1] = tostring(text)
+        return win.write(text)
+    end
+    t.blit = function(text, fg, bg)
+        out[#out + 1] = text
+        return win.blit(text, fg, bg)
+    end
+    t.setCursorPos = function(x, y)
+        local _, cy = win.getCursorPos()
+        if y ~= cy then
+            out[#out + 1] = "\n"
+        end
+        return win.setCursorPos(x, y)
+    end
+    t.scroll = function(n)
+        if n > 0 then
+            out[#out + 1] = string.rep("\n", n)
+        end
+        return win.scroll(n)
+    end
+    return t, function()
+        return table.concat(out)
+    end
+end
+
+-- maxOutput limits the captured output of a program sent with "ccgo_exit"
+local maxOutput = 32768
+
+-- run runs a program like shell.execute in a background process with its own
+-- terminal and returns its pid. When the program stops, "ccgo_exit" is emitted
+-- with the pid, whether the program succeeded and its output.
+function ccgo.run(program, ...)
+    local args = table.pack(...)
+    local t, output = capture(term.current())
+    local run = shell.execute or shell.run
+
+    return start({
+        co = coroutine.create(function()
+            return run(program, table.unpack(args, 1, args.n))
+        end),
+        term = t,
+        onExit = function(pid, ok, result)
+            local out = output()
+            if not ok then
+                out = out .. tostring(result)
+            end
+            ccgo.emit("ccgo_exit", pid, ok and result == true, out:sub(-maxOutput))
+        end,
+    })
+end
+
+-- fetchTimeout is the time in seconds ccgo.fetch waits for a response, it is
+-- a bit longer than the timeout of the proxy (http.ProxyTimeout)
+ccgo.fetchTimeout = 35
+
+-- fetch performs a http request through the proxy of computercraft-go (see
+-- http.Proxy). It accepts a url or a request table like http.request and returns
+-- a response table {code, status, headers, body}, or nil and an error message
+-- ("timeout" if there was no response within ccgo.fetchTimeout).
+function ccgo.fetch(req)
+    if type(req) == "string" then
+        req = { url = req }
+    end
+
+    local id = ccgo.nextRequest
+    ccgo.nextRequest = id + 1
+    ccgo.emit("ccgo_http_request", id, req)
+    -- the proxy may not be running, so the response is not awaited forever
+    local timer = os.startTimer(ccgo.fetchTimeout)
+    while true do
+        local event, rid, res, err = os.pullEvent()
+        if event == "ccgo_http_response" and rid == id then
+            os.cancelTimer(timer)
+            return res, err
+        elseif event == "timer" and rid == timer then
+            return nil, "timeout"
+        end
+    end
+end
+
+local crcTable
+
+-- crc32 returns the crc32 (IEEE) checksum of a string
+local function crc32(s)
+    if not crcTable then
+        crcTable = {}
+        for i = 0, 255 do
+            local c = i
+            for _ = 1, 8 do
+                if bit32.band(c, 1) == 1 then
+                    c = bit32.bxor(0xEDB88320, bit32.rshift(c, 1))
+                else
+                    c = bit32.rshift(c, 1)
+                end
+            end
+            crcTable[i] = c
+        end
+    end
+
+    local crc = 0xFFFFFFFF
+    for i = 1, #s do
+        crc = bit32.bxor(crcTable[bit32.band(bit32.bxor(crc, s:byte(i)), 0xFF)], bit32.rshift(crc, 8))
+    end
+    return bit32.bnot(crc)
+end
+
+-- checksums returns the crc32 checksums of all writable files below dir, keyed
+-- by their path relative to dir. Other drives (e.g. disks) are skipped.
+function ccgo.checksums(dir)
+    local sums = {}
+    local function walk(path, rel)
+        for _, name in ipairs(fs.list(path)) do
+            local full = fs.combine(path, name)
+            local relative = rel == "" and name or rel .. "/" .. name
+            if fs.isReadOnly(full) or fs.isDriveRoot(full) then
+                -- skip rom and mounted drives
+            elseif fs.isDir(full) then
+                walk(full, relative)
+            else
+                local f = fs.open(full, "rb")
+                sums[relative] = crc32(f.readAll() or "")
+                f.close()
+                -- yield, so long walks do not exceed the time limit
+                os.queueEvent("ccgo_yield")
+                os.pullEvent("ccgo_yield")
+            end
+        end
+    end
+    if fs.isDir(dir) then
+        walk(dir, "")
+    end
+    return sums
+end
+
+local mirrorOps = {
+    write = "line", blit = "line", clearLine = "line",
+    clear = "all", scroll = "all",
+    setPaletteColour = "palette", setPaletteColor = "palette",
+}
+
+-- mirror redirects the terminal into a window and streams all changes of the
+-- window to computercraft-go as "ccgo_term" events
+function ccgo.mirror(enable)
+    local m = ccgo.mirrored
+    if enable and not m then
+        local parent = term.current()
+        local w, h = parent.getSize()
+        local win = window.create(parent, 1, 1, w, h, true)
+        m = { parent = parent, window = win, lines = {}, palette = {}, all = true, changed = true }
+
+        local redirect = {}
+        for name, fn in pairs(win) do
+            redirect[name] = function(...)
+                local op = mirrorOps[name]
+                if op == "line" then
+                    local _, y = win.getCursorPos()
+                    m.lines[y] = true
+                elseif op == "all" then
+                    m.all = true
+                elseif op == "palette" then
+                    m.palette[(...)] = true
+                end
+                m.changed = true
+                return fn(...)
+            end
+        end
+        term.redirect(redirect)
+        ccgo.mirrored = m
+    elseif not enable and m then
+        term.redirect(m.parent)
+        ccgo.mirrored = nil
+    end
+    return true
+end
+
+local function flushMirror()
+    local m = ccgo.mirrored
+    if not m or not m.changed then
+        return
+    end
+
+    local win = m.window
+    local w, h = win.getSize()
+    local x, y = win.getCursorPos()
+    local update = {
+        width = w, height = h,
+        cursorX = x, cursorY = y, cursorBlink = win.getCursorBlink(),
+        lines = {}, palette = {},
+    }
+    for line = 1, h do
+        if m.all or m.lines[line] then
+            local text, fg, bg = win.getLine(line)
+            update.lines[line] = { text = text, fg = fg, bg = bg }
+        end
+    end
+    for i = 0, 15 do
+        local colour = 2 ^ i
+        if m.all or m.palette[colour] then
+            update.palette[colour] = { win.getPaletteColour(colour) }
+        end
+    end
+
+    m.lines, m.palette, m.all, m.changed = {}, {}, false, false
+    ccgo.emit("ccgo_term", update)
+end
+
+local function handle(message)
+    log("->", message:gsub("\n", ""))
+    local t = textutils.unserialiseJSON(message)
+
+    local f, err = load(t.func, "=ccgo", "t", programEnv)
+    local ok, result = false, err
+    if f then
+        ok, result = pcall(f)
+    end
+
+    if ok then
+        local resultJson = toJSON(result)
+
+        log("<-", resultJson)
+        ws.send(resultJson)
+    else
+        print(result)
+        ws.send(toJSON({
+            err = tostring(result)
+        }))
+    end
+end
+
 function connect()
     ws, err = http.websocket(addr)
     if not ws then
         log("!!", err)
+        error(err, 0)
     end
 
     printLine()
@@ -60,26 +445,61 @@ function connect()
     print("OUTGOING MESSAGES: <-")
     printLine()
 
-    while true do
-        local message, isBinary = ws.receive()
-        if not isBinary then
-            log("->", message:gsub("\n", ""))
-            t = textutils.unserialiseJSON(message)
-
-            local f, err = loadstring(t.func)
-            if not err then
-                local result = f()
-                local resultJson = textutils.serialiseJSON(result)
-
-                log("<-", resultJson)
-                ws.send(resultJson)
+    ccgo.subscriptions = {}
+    ccgo.processes = {}
+    local queue = {}
+
+    -- commands are executed one after another in their own process, so events
+    -- keep being forwarded while a command waits (e.g. for a turtle to move)
+    ccgo.commands = ccgo.spawn(function()
+        while true do
+            if #queue == 0 then
+                os.pullEvent("ccgo_command")
             else
-                print(err)
-                ws.send(textutils.serialiseJSON({
-                    err = err
-                }))
+                handle(table.remove(queue, 1))
             end
         end
+    end)
+
+    while true do
+        flushMirror()
+        local event = table.pack(os.pullEventRaw())
+        local name = event[1]
+
+        if name == "websocket_message" and event[2] == addr then
+            table.insert(queue, event[3])
+            os.queueEvent("ccgo_command")
+        elseif name == "websocket_closed" and event[2] == addr then
+            error("websocket closed", 0)
+        elseif name == "term_resize" and ccgo.mirrored then
+            local m = ccgo.mirrored
+            local w, h = m.parent.getSize()
+            m.window.reposition(1, 1, w, h)
+            m.all, m.changed = true, true
+        end
+
+        if ccgo.subscriptions[name] then
+            ccgo.emit(table.unpack(event, 1, event.n))
+        end
+
+        local pids = {}
+        for pid in pairs(ccgo.processes) do
+            table.insert(pids, pid)
+        end
+        table.sort(pids)
+        for _, pid in ipairs(pids) do
+            local proc = ccgo.processes[pid]
+            if proc and (proc.filter == nil or proc.filter == name or name == "terminate") then
+                resume(pid, proc, table.unpack(event, 1, event.n))
+            end
+        end
+
+        if not ccgo.processes[ccgo.commands] then
+            error("command loop stopped", 0)
+        end
+        if name == "terminate" then
+            error("Terminated", 0)
+        end
     end
 end
 
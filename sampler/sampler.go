@@ -0,0 +1,83 @@
+package sampler
+
+import (
+	"context"
+	"time"
+)
+
+// Sample is a single reading of a time series
+type Sample[T any] struct {
+	Time  time.Time
+	Value T
+	Err   error
+}
+
+// ReadFunc reads the current value of a time series
+type ReadFunc[T any] func(ctx context.Context) (T, error)
+
+// Poll reads a value immediately and then every interval, until ctx is done. The
+// returned channel is closed afterwards. Failed reads are sent as samples with Err set.
+func Poll[T any](ctx context.Context, interval time.Duration, read ReadFunc[T]) <-chan Sample[T] {
+	samples := make(chan Sample[T])
+	go func() {
+		defer close(samples)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			value, err := read(ctx)
+			if ctx.Err() != nil {
+				return
+			}
+
+			select {
+			case samples <- Sample[T]{Time: time.Now(), Value: value, Err: err}:
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return samples
+}
+
+// Below forwards the samples at which the measured value drops below threshold. A
+// sample is only forwarded again after the value recovered to threshold or above.
+// Failed samples are ignored. The returned channel is closed when samples is closed or ctx is done.
+func Below[T any](ctx context.Context, samples <-chan Sample[T], threshold float64, measure func(T) float64) <-chan Sample[T] {
+	alerts := make(chan Sample[T])
+	go func() {
+		defer close(alerts)
+		below := false
+		for {
+			var sample Sample[T]
+			select {
+			case s, ok := <-samples:
+				if !ok {
+					return
+				}
+				sample = s
+			case <-ctx.Done():
+				return
+			}
+
+			if sample.Err != nil {
+				continue
+			}
+			value := measure(sample.Value)
+			if value < threshold && !below {
+				select {
+				case alerts <- sample:
+				case <-ctx.Done():
+					return
+				}
+			}
+			below = value < threshold
+		}
+	}()
+	return alerts
+}
@@ -0,0 +1,98 @@
+package sampler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestPoll(t *testing.T) {
+	//arrange
+	ctx, cancel := context.WithCancel(context.TODO())
+	defer cancel()
+	calls := 0
+	read := func(ctx context.Context) (int, error) {
+		calls++
+		return calls, nil
+	}
+
+	//act
+	samples := Poll(ctx, time.Millisecond, read)
+	first := <-samples
+	second := <-samples
+	cancel()
+	for range samples {
+	}
+
+	//assert
+	if first.Value != 1 || second.Value != 2 || !second.Time.After(first.Time) {
+		t.FailNow()
+	}
+}
+
+func TestBelow(t *testing.T) {
+	//arrange
+	samples := make(chan Sample[float64])
+	values := []float64{0.9, 0.2, 0.1, 0.5, 0.3, 0.05}
+
+	//act
+	alerts := Below(context.TODO(), samples, 0.25, func(v float64) float64 { return v })
+	go func() {
+		for _, v := range values {
+			samples <- Sample[float64]{Value: v}
+		}
+		samples <- Sample[float64]{Err: errors.New("read failed")}
+		close(samples)
+	}()
+	actual := make([]float64, 0)
+	for alert := range alerts {
+		actual = append(actual, alert.Value)
+	}
+
+	//assert
+	t.Logf("expected: %v", []float64{0.2, 0.05})
+	t.Logf("  actual: %v", actual)
+	if len(actual) != 2 || actual[0] != 0.2 || actual[1] != 0.05 {
+		t.FailNow()
+	}
+}
+
+func TestBelow_canceled(t *testing.T) {
+	//arrange
+	ctx, cancel := context.WithCancel(context.TODO())
+	samples := make(chan Sample[float64])
+	alerts := Below(ctx, samples, 0.25, func(v float64) float64 { return v })
+	samples <- Sample[float64]{Value: 0.1}
+
+	//act
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	_, open := <-alerts
+
+	//assert
+	if open {
+		t.FailNow()
+	}
+}
+
+func TestBelow_canceledWhileWaiting(t *testing.T) {
+	//arrange
+	ctx, cancel := context.WithCancel(context.TODO())
+	samples := make(chan Sample[float64])
+	alerts := Below(ctx, samples, 0.25, func(v float64) float64 { return v })
+
+	//act
+	cancel()
+	var open bool
+	select {
+	case _, open = <-alerts:
+	case <-time.After(time.Second):
+		open = true
+	}
+
+	//assert
+	if open {
+		t.FailNow()
+	}
+}
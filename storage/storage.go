@@ -0,0 +1,406 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/m4schini/computercraft-go/computer/inventory"
+	"github.com/m4schini/computercraft-go/connection"
+	"sort"
+	"sync"
+	"time"
+)
+
+var ErrNotEnoughItems = errors.New("not enough items in storage")
+
+// Location is a stack of items in the storage network
+type Location struct {
+	Inventory string
+	Slot      int
+	Count     int
+}
+
+type contents struct {
+	Size  int                         `lua:"size"`
+	Items map[int]inventory.ItemStack `lua:"items"`
+}
+
+// Network indexes the contents of all inventories on a (wired modem) network.
+// Items can be requested from and deposited into the network, like a simple
+// storage system. The index is only as up to date as the last Scan or Refresh,
+// but Request and Deposit refresh all inventories they touch.
+type Network struct {
+	conn connection.Connection
+
+	mu          sync.RWMutex
+	inventories map[string]*contents
+	excluded    map[string]struct{}
+}
+
+// New creates a storage network. Excluded inventories (e.g. chests used for
+// input and output) are never indexed, requested from or deposited into.
+func New(conn connection.Connection, exclude ...string) *Network {
+	n := &Network{
+		conn:        conn,
+		inventories: make(map[string]*contents),
+		excluded:    make(map[string]struct{}),
+	}
+	for _, name := range exclude {
+		n.excluded[name] = struct{}{}
+	}
+	return n
+}
+
+// Scan discovers all inventories on the network and indexes their contents.
+func (n *Network) Scan(ctx context.Context) error {
+	found, err := inventory.Find(ctx, n.conn)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(found))
+	for _, inv := range found {
+		if _, excluded := n.excluded[inv.Name()]; !excluded {
+			names = append(names, inv.Name())
+		}
+	}
+
+	listed, err := n.list(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	n.inventories = listed
+	return nil
+}
+
+// Refresh updates the index of the given inventories, or of all indexed
+// inventories if no names are given. Given inventories, that are not indexed
+// yet, are added to the index (unless they are excluded).
+func (n *Network) Refresh(ctx context.Context, names ...string) error {
+	if len(names) == 0 {
+		names = n.Inventories()
+	}
+	included := make([]string, 0, len(names))
+	for _, name := range names {
+		if _, excluded := n.excluded[name]; !excluded {
+			included = append(included, name)
+		}
+	}
+	names = included
+
+	listed, err := n.list(ctx, names)
+	if err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	for _, name := range names {
+		if c, ok := listed[name]; ok {
+			n.inventories[name] = c
+		} else {
+			delete(n.inventories, name)
+		}
+	}
+	return nil
+}
+
+// list lists the contents of multiple inventories in a single round trip.
+// Inventories that are no longer present (or are no inventories) are omitted.
+func (n *Network) list(ctx context.Context, names []string) (map[string]*contents, error) {
+	listed := make(map[string]*contents)
+	if len(names) == 0 {
+		return listed, nil
+	}
+
+	arg, err := connection.LuaValue(names)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := n.conn.Execute(ctx, fmt.Sprintf(
+		"(function(names) local r = {} for _, name in ipairs(names) do if peripheral.isPresent(name) and peripheral.hasType(name, \"inventory\") then r[name] = {size = peripheral.call(name, \"size\"), items = peripheral.call(name, \"list\")} end end return r end)(%v)",
+		arg))
+	if err != nil {
+		return nil, connection.RpcError(err)
+	}
+
+	err = connection.Unpack(res, &listed)
+	if listed == nil {
+		listed = make(map[string]*contents)
+	}
+	return listed, err
+}
+
+// Inventories returns the names of all indexed inventories
+func (n *Network) Inventories() []string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	names := make([]string, 0, len(n.inventories))
+	for name := range n.inventories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Items returns the total count of every item in the network
+func (n *Network) Items() map[string]int {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	items := make(map[string]int)
+	for _, c := range n.inventories {
+		for _, stack := range c.Items {
+			items[stack.Name] += stack.Count
+		}
+	}
+	return items
+}
+
+// Count returns the total count of an item in the network
+func (n *Network) Count(item string) int {
+	count := 0
+	for _, location := range n.Locations(item) {
+		count += location.Count
+	}
+	return count
+}
+
+// Locations returns all stacks of an item, smallest stacks first
+func (n *Network) Locations(item string) []Location {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	locations := make([]Location, 0)
+	for name, c := range n.inventories {
+		for slot, stack := range c.Items {
+			if stack.Name == item {
+				locations = append(locations, Location{Inventory: name, Slot: slot, Count: stack.Count})
+			}
+		}
+	}
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].Count != locations[j].Count {
+			return locations[i].Count < locations[j].Count
+		}
+		if locations[i].Inventory != locations[j].Inventory {
+			return locations[i].Inventory < locations[j].Inventory
+		}
+		return locations[i].Slot < locations[j].Slot
+	})
+	return locations
+}
+
+// Request moves count items from the network into the inventory toName. Smaller
+// stacks are used first. It returns the number of moved items and
+// ErrNotEnoughItems if the network does not contain enough items.
+func (n *Network) Request(ctx context.Context, item string, count int, toName string) (int, error) {
+	to, err := inventory.Wrap(ctx, n.conn, toName)
+	if err != nil {
+		return 0, err
+	}
+
+	moved := 0
+	touched := make(map[string]struct{})
+	for _, location := range n.Locations(item) {
+		if moved >= count {
+			break
+		}
+		if location.Inventory == toName {
+			continue
+		}
+
+		limit := location.Count
+		if count-moved < limit {
+			limit = count - moved
+		}
+		transferred, err := to.PullItems(ctx, location.Inventory, location.Slot, limit, 0)
+		moved += transferred
+		touched[location.Inventory] = struct{}{}
+		if err != nil {
+			return moved, err
+		}
+		if transferred < limit {
+			// the target inventory is full
+			break
+		}
+	}
+	if len(touched) > 0 && n.indexed(toName) {
+		touched[toName] = struct{}{}
+	}
+
+	err = n.refreshTouched(ctx, touched)
+	if err != nil {
+		return moved, err
+	}
+	if moved < count && n.Count(item) == 0 {
+		return moved, fmt.Errorf("%w: %v (%v of %v)", ErrNotEnoughItems, item, moved, count)
+	}
+	return moved, nil
+}
+
+// Deposit moves all items from the inventory fromName into the network. Items are
+// merged into existing stacks first. It returns the number of moved items, items
+// that didn't fit remain in fromName.
+func (n *Network) Deposit(ctx context.Context, fromName string) (int, error) {
+	from, err := inventory.Wrap(ctx, n.conn, fromName)
+	if err != nil {
+		return 0, err
+	}
+
+	items, err := from.List(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	slots := make([]int, 0, len(items))
+	for slot := range items {
+		slots = append(slots, slot)
+	}
+	sort.Ints(slots)
+
+	moved := 0
+	touched := make(map[string]struct{})
+	for _, slot := range slots {
+		stack := items[slot]
+		remaining := stack.Count
+		for _, target := range n.targets(stack.Name, fromName) {
+			transferred, err := from.PushItems(ctx, target, slot, remaining, 0)
+			moved += transferred
+			remaining -= transferred
+			if transferred > 0 {
+				touched[target] = struct{}{}
+			}
+			if err != nil {
+				return moved, err
+			}
+			if remaining <= 0 {
+				break
+			}
+		}
+	}
+
+	if len(touched) > 0 && n.indexed(fromName) {
+		touched[fromName] = struct{}{}
+	}
+	return moved, n.refreshTouched(ctx, touched)
+}
+
+// indexed checks if an inventory is in the index
+func (n *Network) indexed(name string) bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	_, ok := n.inventories[name]
+	return ok
+}
+
+// refreshTouched refreshes the inventories changed by Request or Deposit. Nothing is
+// refreshed if no inventory was touched, Refresh would refresh all inventories instead.
+func (n *Network) refreshTouched(ctx context.Context, touched map[string]struct{}) error {
+	if len(touched) == 0 {
+		return nil
+	}
+	names := make([]string, 0, len(touched))
+	for name := range touched {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return n.Refresh(ctx, names...)
+}
+
+// targets returns the inventories an item should be deposited into: inventories
+// already containing the item first, then inventories with free slots.
+func (n *Network) targets(item, except string) []string {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+	containing := make([]string, 0)
+	free := make([]string, 0)
+	for name, c := range n.inventories {
+		if name == except {
+			continue
+		}
+		hasItem := false
+		for _, stack := range c.Items {
+			if stack.Name == item {
+				hasItem = true
+				break
+			}
+		}
+		if hasItem {
+			containing = append(containing, name)
+		} else if len(c.Items) < c.Size {
+			free = append(free, name)
+		}
+	}
+	sort.Strings(containing)
+	sort.Strings(free)
+	return append(containing, free...)
+}
+
+// WatchBatchSize is the number of inventories refreshed by Watch at once
+const WatchBatchSize = 8
+
+// Watch keeps the index up to date until ctx is done. Every interval, and whenever
+// the inventory of the turtle changes, the next WatchBatchSize inventories are
+// refreshed, so all inventories are polled in turns. Inventories are added to and
+// removed from the index when they are attached or detached.
+func (n *Network) Watch(ctx context.Context, interval time.Duration) error {
+	events, err := n.conn.Subscribe(ctx, "turtle_inventory", "peripheral", "peripheral_detach")
+	if err != nil {
+		return err
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	last := ""
+	poll := func() error {
+		batch := n.nextBatch(last, WatchBatchSize)
+		if len(batch) == 0 {
+			return nil
+		}
+		last = batch[len(batch)-1]
+		return n.Refresh(ctx, batch...)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case event, ok := <-events:
+			if !ok {
+				return ctx.Err()
+			}
+			var name string
+			if event.Name == "turtle_inventory" {
+				err = poll()
+			} else if event.Arg(0, &name) == nil {
+				err = n.Refresh(ctx, name)
+			}
+		case <-ticker.C:
+			err = poll()
+		}
+		if err != nil && ctx.Err() == nil {
+			return err
+		}
+	}
+}
+
+// nextBatch returns up to size indexed inventories, that follow after the inventory last (by name)
+func (n *Network) nextBatch(last string, size int) []string {
+	names := n.Inventories()
+	if size > len(names) {
+		size = len(names)
+	}
+
+	start := sort.SearchStrings(names, last)
+	if start < len(names) && names[start] == last {
+		start++
+	}
+	batch := make([]string, 0, size)
+	for i := 0; i < size; i++ {
+		batch = append(batch, names[(start+i)%len(names)])
+	}
+	return batch
+}
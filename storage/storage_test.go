@@ -0,0 +1,162 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"github.com/m4schini/computercraft-go/test"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func describe(name string) map[string]any {
+	return test.Peripheral(name, []string{"minecraft:chest", "inventory"}, []string{"size", "list", "pushItems", "pullItems"})
+}
+
+func newTestNetwork(t *testing.T) (*test.ConnectionMock, *Network) {
+	conn := &test.ConnectionMock{}
+	conn.Respond(
+		[]any{[]any{describe("chest_0"), describe("chest_1"), describe("output")}},
+		[]any{map[string]any{
+			"chest_0": map[string]any{"size": float64(27), "items": map[string]any{
+				"1": map[string]any{"name": "minecraft:coal", "count": float64(64)},
+				"4": map[string]any{"name": "minecraft:coal", "count": float64(3)},
+			}},
+			"chest_1": map[string]any{"size": float64(27), "items": []any{
+				map[string]any{"name": "minecraft:iron_ingot", "count": float64(12)},
+			}},
+		}},
+	)
+	n := New(conn, "output")
+	err := n.Scan(context.TODO())
+	if err != nil {
+		t.Fatal(err)
+	}
+	conn.Reset()
+	return conn, n
+}
+
+func TestNetwork_Scan(t *testing.T) {
+	//arrange
+	_, n := newTestNetwork(t)
+
+	//act
+	items := n.Items()
+
+	//assert
+	t.Logf("items: %v", items)
+	if items["minecraft:coal"] != 67 || items["minecraft:iron_ingot"] != 12 || len(n.Inventories()) != 2 {
+		t.FailNow()
+	}
+}
+
+func TestNetwork_Request(t *testing.T) {
+	//arrange
+	conn, n := newTestNetwork(t)
+	conn.Respond(
+		[]any{describe("output")},
+		[]any{float64(3)},
+		[]any{float64(7)},
+		[]any{map[string]any{
+			"chest_0": map[string]any{"size": float64(27), "items": map[string]any{
+				"1": map[string]any{"name": "minecraft:coal", "count": float64(57)},
+			}},
+		}},
+	)
+
+	//act
+	moved, err := n.Request(context.TODO(), "minecraft:coal", 10, "output")
+
+	//assert
+	commands := conn.Executed()
+	t.Logf("commands: %v", commands)
+	if err != nil || moved != 10 || n.Count("minecraft:coal") != 57 {
+		t.FailNow()
+	}
+	if !strings.Contains(commands[1], `"pullItems", "chest_0", 4, 3, nil`) || !strings.Contains(commands[2], `"pullItems", "chest_0", 1, 7, nil`) {
+		t.FailNow()
+	}
+}
+
+func TestNetwork_Request_refreshesTarget(t *testing.T) {
+	//arrange
+	conn, n := newTestNetwork(t)
+	conn.Respond(
+		[]any{describe("chest_1")},
+		[]any{float64(3)},
+	)
+
+	//act
+	_, err := n.Request(context.TODO(), "minecraft:coal", 3, "chest_1")
+
+	//assert
+	commands := conn.Executed()
+	t.Logf("commands: %v", commands)
+	if err != nil || len(commands) != 3 || !strings.Contains(commands[2], `({"chest_0", "chest_1"})`) {
+		t.FailNow()
+	}
+}
+
+func TestNetwork_Request_nothingFound(t *testing.T) {
+	//arrange
+	conn, n := newTestNetwork(t)
+	conn.Respond([]any{describe("output")})
+
+	//act
+	_, err := n.Request(context.TODO(), "minecraft:diamond", 1, "output")
+
+	//assert
+	commands := conn.Executed()
+	t.Logf("actual: %v %v", err, commands)
+	if !errors.Is(err, ErrNotEnoughItems) || len(commands) != 1 {
+		t.FailNow()
+	}
+}
+
+func TestNetwork_nextBatch(t *testing.T) {
+	//arrange
+	_, n := newTestNetwork(t)
+	var expected = [][]string{{"chest_0"}, {"chest_1"}, {"chest_0"}}
+
+	//act
+	var actual [][]string
+	last := ""
+	for range expected {
+		batch := n.nextBatch(last, 1)
+		last = batch[len(batch)-1]
+		actual = append(actual, batch)
+	}
+
+	//assert
+	if !reflect.DeepEqual(expected, actual) || len(n.nextBatch("", 8)) != 2 {
+		t.Logf("expected: %v", expected)
+		t.Logf("actual  : %v", actual)
+		t.FailNow()
+	}
+}
+
+func TestNetwork_Watch_attach(t *testing.T) {
+	//arrange
+	conn, n := newTestNetwork(t)
+	conn.Respond([]any{map[string]any{
+		"chest_2": map[string]any{"size": float64(27), "items": map[string]any{}},
+	}})
+	ctx, cancel := context.WithTimeout(context.TODO(), time.Second)
+	defer cancel()
+	go n.Watch(ctx, time.Hour)
+	time.Sleep(10 * time.Millisecond)
+
+	//act
+	conn.Emit("peripheral", "chest_2")
+	for len(n.Inventories()) < 3 && ctx.Err() == nil {
+		time.Sleep(time.Millisecond)
+	}
+
+	//assert
+	commands := conn.Executed()
+	t.Logf("actual: %v %v", n.Inventories(), commands)
+	if len(n.Inventories()) != 3 || len(commands) != 1 || !strings.HasSuffix(commands[0], `({"chest_2"})`) {
+		t.FailNow()
+	}
+}
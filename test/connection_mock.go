@@ -0,0 +1,83 @@
+package test
+
+import (
+	"context"
+	"github.com/m4schini/computercraft-go/connection"
+	"sync"
+)
+
+// ConnectionMock records all executed commands and answers them with the queued
+// responses (or with Handler or an empty response, if none are queued). Events can be injected with Emit.
+type ConnectionMock struct {
+	mu        sync.Mutex
+	Commands  []string
+	Responses [][]any
+	// Handler answers commands, while no responses are queued
+	Handler func(command string) []any
+
+	subscribers []mockSubscriber
+}
+
+type mockSubscriber struct {
+	ctx    context.Context
+	names  map[string]struct{}
+	events chan connection.Event
+}
+
+func (c *ConnectionMock) Execute(ctx context.Context, command string) ([]any, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Commands = append(c.Commands, command)
+	if len(c.Responses) == 0 {
+		if c.Handler != nil {
+			return c.Handler(command), nil
+		}
+		return []any{}, nil
+	}
+	res := c.Responses[0]
+	c.Responses = c.Responses[1:]
+	return res, nil
+}
+
+func (c *ConnectionMock) Subscribe(ctx context.Context, events ...string) (<-chan connection.Event, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	s := mockSubscriber{ctx: ctx, names: make(map[string]struct{}), events: make(chan connection.Event, 64)}
+	for _, name := range events {
+		s.names[name] = struct{}{}
+	}
+	c.subscribers = append(c.subscribers, s)
+	return s.events, nil
+}
+
+// Respond queues responses for the next executions
+func (c *ConnectionMock) Respond(responses ...[]any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Responses = append(c.Responses, responses...)
+}
+
+// Reset removes all recorded commands
+func (c *ConnectionMock) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Commands = nil
+}
+
+// Executed returns a copy of all recorded commands
+func (c *ConnectionMock) Executed() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string{}, c.Commands...)
+}
+
+// Emit sends an event to all subscribers of its name
+func (c *ConnectionMock) Emit(name string, args ...any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, s := range c.subscribers {
+		if _, ok := s.names[name]; ok && s.ctx.Err() == nil {
+			s.events <- connection.Event{Name: name, Args: args}
+		}
+	}
+}
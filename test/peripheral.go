@@ -0,0 +1,19 @@
+package test
+
+// Peripheral returns the description of a peripheral, as returned by the lua runtime
+// when a peripheral is wrapped or listed.
+func Peripheral(name string, types, methods []string) map[string]any {
+	return map[string]any{
+		"name":    name,
+		"types":   toAny(types),
+		"methods": toAny(methods),
+	}
+}
+
+func toAny(values []string) []any {
+	res := make([]any, len(values))
+	for i, v := range values {
+		res[i] = v
+	}
+	return res
+}